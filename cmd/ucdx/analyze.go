@@ -13,6 +13,8 @@ import (
 	"github.com/nihei9/ucdx/db"
 	"github.com/nihei9/ucdx/ucd"
 	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/nihei9/ucdx/ucd/segment"
+	"github.com/nihei9/ucdx/ucd/width"
 	"github.com/spf13/cobra"
 )
 
@@ -21,8 +23,15 @@ var analyzeOutputSet = []string{
 	"json",
 }
 
+var analyzeBySet = []string{
+	"char",
+	"grapheme",
+}
+
 type analyzeFlagSet struct {
-	output *string
+	output     *string
+	by         *string
+	properties *[]string
 }
 
 func (f *analyzeFlagSet) validate() error {
@@ -42,6 +51,22 @@ func (f *analyzeFlagSet) validate() error {
 		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
 	}
 
+	passed = false
+	for _, by := range analyzeBySet {
+		if *f.by == by {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, analyzeBySet[0])
+		for _, by := range analyzeBySet[1:] {
+			fmt.Fprint(&b, ", ", by)
+		}
+		return fmt.Errorf("--by doesn't support %v, allowed values are: %v", *f.by, b.String())
+	}
+
 	return nil
 }
 
@@ -51,11 +76,16 @@ func init() {
 	cmd := &cobra.Command{
 		Use:   "analyze",
 		Short: "Analyze characters and print their properties",
-		Long:  `analyze analyzes characters and print their properties.`,
-		Args:  cobra.MaximumNArgs(1),
-		RunE:  runAnalyze,
+		Long: `analyze analyzes characters and print their properties.
+It prints the default properties unless --properties specifies some of them by any of their aliases.`,
+		Example: `  ucdx analyze "a"
+  ucdx analyze --properties gc,Alphabetic,Math "a"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAnalyze,
 	}
 	analyzeFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	analyzeFlags.by = cmd.Flags().String("by", "char", "Unit of analysis. One of: char|grapheme")
+	analyzeFlags.properties = cmd.Flags().StringSliceP("properties", "p", nil, "Properties to print. The default properties are printed by default")
 	rootCmd.AddCommand(cmd)
 }
 
@@ -79,6 +109,11 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	names, err := selectProperties(u, *analyzeFlags.properties)
+	if err != nil {
+		return err
+	}
+
 	var src io.Reader
 	if len(args) > 0 {
 		src = strings.NewReader(args[0])
@@ -86,7 +121,7 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		src = os.Stdin
 	}
 	r := bufio.NewReader(src)
-	results := []*ucd.PropertySet{}
+	var cs []rune
 	for {
 		c, _, err := r.ReadRune()
 		if err != nil {
@@ -98,14 +133,53 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		if c == unicode.ReplacementChar {
 			continue
 		}
+		cs = append(cs, c)
+	}
 
-		props := u.AnalizeCodePoint(c)
+	if *analyzeFlags.by == "grapheme" {
+		b := segment.NewGraphemeBreaker(u.GraphemeBreakProperty, u.EmojiData)
+		results := [][]*ucd.PropertySet{}
+		for _, cluster := range segment.Split(b, cs) {
+			props := make([]*ucd.PropertySet, len(cluster))
+			for i, c := range cluster {
+				props[i], err = u.AnalizeCodePointProperties(c, names)
+				if err != nil {
+					return err
+				}
+			}
+			results = append(results, props)
+		}
+
+		switch *analyzeFlags.output {
+		case "table":
+			wc := newWidthCalculator(u, width.AmbiguousNarrow)
+			for i, props := range results {
+				printClusterHeader(i, props)
+				printPropertySetAsTable(props, names, wc)
+			}
+		case "json":
+			b, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+		}
+
+		return nil
+	}
+
+	results := []*ucd.PropertySet{}
+	for _, c := range cs {
+		props, err := u.AnalizeCodePointProperties(c, names)
+		if err != nil {
+			return err
+		}
 		results = append(results, props)
 	}
 
 	switch *analyzeFlags.output {
 	case "table":
-		printPropertySetAsTable(results)
+		printPropertySetAsTable(results, names, newWidthCalculator(u, width.AmbiguousNarrow))
 	case "json":
 		b, err := json.Marshal(results)
 		if err != nil {
@@ -117,9 +191,42 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printPropertySetAsTable(ps []*ucd.PropertySet) {
+func printClusterHeader(n int, ps []*ucd.PropertySet) {
+	var cluster strings.Builder
+	var cps strings.Builder
+	for i, p := range ps {
+		fmt.Fprint(&cluster, string(p.CP))
+		if i > 0 {
+			fmt.Fprint(&cps, " ")
+		}
+		fmt.Fprintf(&cps, "U+%X", p.CP)
+	}
+	fmt.Printf("=== Grapheme Cluster #%v: %v (%v)\n", n+1, cluster.String(), cps.String())
+}
+
+// selectProperties returns the long names of the specified properties. When no property is specified, it returns
+// the default properties.
+func selectProperties(u *ucd.UCD, specified []string) ([]property.PropertyName, error) {
+	if len(specified) == 0 {
+		return ucd.DefaultPropertyNames, nil
+	}
+
+	names := make([]property.PropertyName, len(specified))
+	for i, s := range specified {
+		name, err := u.ResolvePropertyName(s)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// printPropertySetAsTable prints property sets. A character occupies two columns regardless of its width so that the
+// code points following it are aligned.
+func printPropertySetAsTable(ps []*ucd.PropertySet, names []property.PropertyName, wc *width.Calculator) {
 	for _, p := range ps {
-		fmt.Println(string(p.CP), fmt.Sprintf("U+%X", p.CP))
+		fmt.Println(padColumn(wc, string(p.CP), 2), fmt.Sprintf("U+%X", p.CP))
 		var opts []string
 		if len(p.GeneralCategoryGroups) > 0 {
 			var gs strings.Builder
@@ -131,22 +238,23 @@ func printPropertySetAsTable(ps []*ucd.PropertySet) {
 				fmt.Sprintf("(%v)", gs.String()),
 			}
 		}
-		printProperty(p.Lookup(property.PropNameName))
-		printProperty(p.Lookup(property.PropNameNameAlias))
-		printProperty(p.Lookup(property.PropNameGeneralCategory), opts...)
-		printProperty(p.Lookup(property.PropNameAlphabetic))
-		printProperty(p.Lookup(property.PropNameLowercase))
-		printProperty(p.Lookup(property.PropNameUppercase))
-		printProperty(p.Lookup(property.PropNameIDStart))
-		printProperty(p.Lookup(property.PropNameIDContinue))
-		printProperty(p.Lookup(property.PropNameXIDStart))
-		printProperty(p.Lookup(property.PropNameXIDContinue))
-		printProperty(p.Lookup(property.PropNameWhiteSpace))
+		for _, name := range names {
+			switch name {
+			case property.PropNameGeneralCategory:
+				printProperty(p.Lookup(name), opts...)
+			case property.PropNameScript:
+				printProperty(p.Lookup(name), fmt.Sprintf("(%v)", p.ScriptLongName))
+			case property.PropNameBlock:
+				printProperty(p.Lookup(name), fmt.Sprintf("(%v)", p.BlockLongName))
+			default:
+				printProperty(p.Lookup(name))
+			}
+		}
 	}
 }
 
 func printProperty(prop *property.Property, opts ...string) {
-	fmt.Printf("%-20v: %v", prop.Name, prop.Value)
+	fmt.Printf("%-25v: %v", prop.Name, prop.Value)
 	for _, opt := range opts {
 		fmt.Printf(" %v", opt)
 	}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/bidi"
+	"github.com/spf13/cobra"
+)
+
+var bidiOutputSet = []string{
+	"table",
+	"json",
+}
+
+type bidiFlagSet struct {
+	direction *string
+	output    *string
+}
+
+func (f *bidiFlagSet) validate() error {
+	_, err := bidi.ParseDirection(*f.direction)
+	if err != nil {
+		return fmt.Errorf("--direction doesn't support %v, allowed values are: auto, ltr, rtl", *f.direction)
+	}
+
+	passed := false
+	for _, o := range bidiOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, bidiOutputSet[0])
+		for _, o := range bidiOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var bidiFlags = &bidiFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "bidi",
+		Short: "Resolve bidirectional text",
+		Long: `bidi resolves the embedding levels of text following UAX #9 and prints them with the visual order.
+Characters in right-to-left runs are substituted with their mirrored glyphs.
+The text is split into paragraphs after paragraph separators, and each paragraph is treated as a single line.
+The text is read from the argument or the standard input.`,
+		Example: `  ucdx bidi "abc (אבג) def"
+  ucdx bidi --direction rtl "abc (אבג) def"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runBidi,
+	}
+	bidiFlags.direction = cmd.Flags().StringP("direction", "d", "auto", "Paragraph direction. One of: auto|ltr|rtl")
+	bidiFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+type bidiParagraph struct {
+	Level       int              `json:"level"`
+	Characters  []*bidiCharacter `json:"characters"`
+	VisualOrder []int            `json:"visual_order"`
+	Visual      string           `json:"visual"`
+}
+
+// bidiCharacter is a character of a resolved paragraph. `Index` is an index of the character in the whole text, and
+// `Level` is -1 when rule X9 removes the character. `Mirrored` is the mirrored glyph substituted for the character.
+type bidiCharacter struct {
+	Index    int        `json:"index"`
+	CP       rune       `json:"code_point"`
+	Class    bidi.Class `json:"class"`
+	Level    int        `json:"level"`
+	Mirrored rune       `json:"mirrored,omitempty"`
+}
+
+func runBidi(cmd *cobra.Command, args []string) error {
+	err := bidiFlags.validate()
+	if err != nil {
+		return err
+	}
+	dir, _ := bidi.ParseDirection(*bidiFlags.direction)
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var src io.Reader
+	if len(args) > 0 {
+		src = strings.NewReader(args[0])
+	} else {
+		src = os.Stdin
+	}
+	r := bufio.NewReader(src)
+	var cs []rune
+	for {
+		c, _, err := r.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		cs = append(cs, c)
+	}
+
+	resolver := bidi.NewResolver(u.UnicodeData, u.BidiBrackets, u.BidiMirroring)
+	paras := []*bidiParagraph{}
+	for start := 0; start < len(cs); {
+		// Rule P1: a paragraph separator is kept with the preceding paragraph.
+		end := start
+		for end < len(cs) {
+			end++
+			if resolver.Class(cs[end-1]) == bidi.B {
+				break
+			}
+		}
+		paras = append(paras, resolveBidiParagraph(resolver, cs[start:end], start, dir))
+		start = end
+	}
+
+	switch *bidiFlags.output {
+	case "table":
+		for i, p := range paras {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("=== Paragraph #%v: level %v\n", i+1, p.Level)
+			fmt.Printf("%6v  %-10v  %-6v  %-5v  %-5v  %v\n", "Index", "Code Point", "Char", "Class", "Level", "Mirrored")
+			for _, c := range p.Characters {
+				level := "x"
+				if c.Level >= 0 {
+					level = fmt.Sprint(c.Level)
+				}
+				mirrored := ""
+				if c.Mirrored != 0 {
+					mirrored = fmt.Sprintf("U+%04X %q", c.Mirrored, c.Mirrored)
+				}
+				fmt.Printf("%6v  %-10v  %-6q  %-5v  %-5v  %v\n", c.Index, fmt.Sprintf("U+%04X", c.CP), c.CP, c.Class, level, mirrored)
+			}
+			var order strings.Builder
+			for j, idx := range p.VisualOrder {
+				if j > 0 {
+					fmt.Fprint(&order, " ")
+				}
+				fmt.Fprint(&order, idx)
+			}
+			fmt.Printf("Visual order: %v\n", order.String())
+			fmt.Printf("Visual: %q\n", p.Visual)
+		}
+	case "json":
+		b, err := json.Marshal(paras)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
+
+// resolveBidiParagraph resolves a paragraph starting at the index `offset` of the whole text. The visual text
+// consists of the characters in visual order, substituted with their mirrored glyphs.
+func resolveBidiParagraph(r *bidi.Resolver, cs []rune, offset int, dir bidi.Direction) *bidiParagraph {
+	p := r.Resolve(cs, dir)
+	chars := make([]*bidiCharacter, len(cs))
+	for i, c := range cs {
+		chars[i] = &bidiCharacter{
+			Index: offset + i,
+			CP:    c,
+			Class: r.Class(c),
+			Level: p.Levels[i],
+		}
+		if m, ok := r.Mirror(c, p.Levels[i]); ok {
+			chars[i].Mirrored = m
+		}
+	}
+
+	order := p.VisualOrder()
+	var visual strings.Builder
+	for i, idx := range order {
+		c := chars[idx]
+		if c.Mirrored != 0 {
+			visual.WriteRune(c.Mirrored)
+		} else {
+			visual.WriteRune(c.CP)
+		}
+		order[i] = c.Index
+	}
+
+	return &bidiParagraph{
+		Level:       p.Level,
+		Characters:  chars,
+		VisualOrder: order,
+		Visual:      visual.String(),
+	}
+}
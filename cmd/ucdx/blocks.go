@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/spf13/cobra"
+)
+
+var blocksOutputSet = []string{
+	"table",
+	"json",
+}
+
+type blocksFlagSet struct {
+	output *string
+}
+
+func (f *blocksFlagSet) validate() error {
+	passed := false
+	for _, o := range blocksOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, blocksOutputSet[0])
+		for _, o := range blocksOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var blocksFlags = &blocksFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "blocks",
+		Short: "List all blocks",
+		Long:  `blocks lists all blocks with their ranges, the number of assigned code points, and their aliases.`,
+		Args:  cobra.NoArgs,
+		RunE:  runBlocks,
+	}
+	blocksFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+type blockSummary struct {
+	CP         *property.CodePointRange     `json:"cp"`
+	Assigned   int                          `json:"assigned"`
+	Unassigned int                          `json:"unassigned"`
+	Abb        property.PropertyValueSymbol `json:"abb"`
+	Long       property.PropertyValueSymbol `json:"long"`
+}
+
+func runBlocks(cmd *cobra.Command, args []string) error {
+	err := blocksFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	blocks := make([]*blockSummary, len(u.Blocks.Entries))
+	for i, e := range u.Blocks.Entries {
+		from, to := e.CP.Range()
+		assigned := u.CountAssignedCodePoints(e.CP)
+		alias := u.LookupPropertyValueAliase(property.PropNameBlock, e.Block)
+		blocks[i] = &blockSummary{
+			CP:         e.CP,
+			Assigned:   assigned,
+			Unassigned: int(to-from) + 1 - assigned,
+			Abb:        alias.Abb,
+			Long:       alias.Long,
+		}
+	}
+
+	switch *blocksFlags.output {
+	case "table":
+		fmt.Printf("%-14v %8v %10v  %-24v %v\n", "Range", "Assigned", "Unassigned", "Abbreviation", "Long Name")
+		for _, b := range blocks {
+			fmt.Printf("%-14v %8v %10v  %-24v %v\n", b.CP, b.Assigned, b.Unassigned, b.Abb, b.Long)
+		}
+	case "json":
+		b, err := json.Marshal(blocks)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
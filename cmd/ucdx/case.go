@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/casing"
+	"github.com/spf13/cobra"
+)
+
+type caseFlagSet struct {
+	to     *string
+	locale *string
+}
+
+func (f *caseFlagSet) validate() error {
+	_, err := casing.ParseMapping(*f.to)
+	if err != nil {
+		return fmt.Errorf("--to doesn't support %v, allowed values are: upper, lower, title, fold", *f.to)
+	}
+
+	_, err = casing.ParseLanguage(*f.locale)
+	if err != nil {
+		return fmt.Errorf("--locale doesn't support %v, allowed values are: tr, lt, az", *f.locale)
+	}
+
+	return nil
+}
+
+var caseFlags = &caseFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "case",
+		Short: "Convert the case of text",
+		Long: `case converts the case of text using the full case mappings and the full case folding.
+The context-sensitive mappings such as Final_Sigma apply, and the language-specific ones apply when --locale is specified.
+The title case conversion capitalizes the first cased character of each word following UAX #29.
+The text is read from the argument or the standard input.`,
+		Example: `  ucdx case --to upper "straße"
+  ucdx case --to lower "ΟΔΟΣ"
+  ucdx case --to upper --locale tr "istanbul"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCase,
+	}
+	caseFlags.to = cmd.Flags().StringP("to", "t", "upper", "Case to convert text to. One of: upper|lower|title|fold")
+	caseFlags.locale = cmd.Flags().StringP("locale", "l", "", "Language of text. One of: tr|lt|az")
+	rootCmd.AddCommand(cmd)
+}
+
+func runCase(cmd *cobra.Command, args []string) error {
+	err := caseFlags.validate()
+	if err != nil {
+		return err
+	}
+	mapping, _ := casing.ParseMapping(*caseFlags.to)
+	lang, _ := casing.ParseLanguage(*caseFlags.locale)
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var src string
+	if len(args) > 0 {
+		src = args[0]
+	} else {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		src = string(b)
+	}
+
+	wb, err := newBreaker(u, "word")
+	if err != nil {
+		return err
+	}
+	m := casing.NewMapper(u.UnicodeData, u.SpecialCasing, u.CaseFolding, u.DerivedCoreProperties, u.PropList, wb)
+	fmt.Print(m.String(src, mapping, lang))
+	if len(args) > 0 {
+		fmt.Println()
+	}
+
+	return nil
+}
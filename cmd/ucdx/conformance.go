@@ -0,0 +1,322 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/bidi"
+	"github.com/nihei9/ucdx/ucd/conformance"
+	"github.com/nihei9/ucdx/ucd/normalize"
+	"github.com/nihei9/ucdx/ucd/parser"
+	"github.com/spf13/cobra"
+)
+
+var conformanceOutputSet = []string{
+	"table",
+	"json",
+}
+
+type conformanceFlagSet struct {
+	output *string
+}
+
+func (f *conformanceFlagSet) validate() error {
+	passed := false
+	for _, o := range conformanceOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, conformanceOutputSet[0])
+		for _, o := range conformanceOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var conformanceFlags = &conformanceFlagSet{}
+
+var segmentationKindSet = []string{
+	"grapheme",
+	"word",
+	"sentence",
+	"line",
+}
+
+// segmentationTestFileNames maps the kinds of segmentation to their test data files.
+var segmentationTestFileNames = map[string]string{
+	"grapheme": ucd.TxtGraphemeBreakTest,
+	"word":     ucd.TxtWordBreakTest,
+	"sentence": ucd.TxtSentenceBreakTest,
+	"line":     ucd.TxtLineBreakTest,
+}
+
+type conformanceSegmentationFlagSet struct {
+	kind *string
+}
+
+func (f *conformanceSegmentationFlagSet) validate() error {
+	passed := false
+	for _, k := range segmentationKindSet {
+		if *f.kind == k {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, segmentationKindSet[0])
+		for _, k := range segmentationKindSet[1:] {
+			fmt.Fprint(&b, ", ", k)
+		}
+		return fmt.Errorf("--kind doesn't support %v, allowed values are: %v", *f.kind, b.String())
+	}
+
+	return nil
+}
+
+var conformanceSegmentationFlags = &conformanceSegmentationFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "conformance",
+		Short: "Run conformance tests",
+		Long: `conformance runs the conformance tests the UCD provides against the implementations in ucdx.
+The test data files are downloaded by the setup command.`,
+	}
+	conformanceFlags.output = cmd.PersistentFlags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "normalization",
+		Short: "Run the normalization conformance test",
+		Long:  `normalization runs NormalizationTest.txt against the normalization forms ucdx implements.`,
+		Args:  cobra.NoArgs,
+		RunE:  runConformanceNormalization,
+	})
+
+	segCmd := &cobra.Command{
+		Use:   "segmentation",
+		Short: "Run the text segmentation conformance tests",
+		Long: `segmentation runs GraphemeBreakTest.txt, WordBreakTest.txt, SentenceBreakTest.txt, or LineBreakTest.txt
+against the segmentation algorithms ucdx implements.`,
+		Example: `  ucdx conformance segmentation --kind grapheme`,
+		Args:    cobra.NoArgs,
+		RunE:    runConformanceSegmentation,
+	}
+	conformanceSegmentationFlags.kind = segCmd.Flags().StringP("kind", "k", "grapheme", "Kind of segmentation. One of: grapheme|word|sentence|line")
+	cmd.AddCommand(segCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "bidi",
+		Short: "Run the bidirectional algorithm conformance tests",
+		Long:  `bidi runs BidiTest.txt and BidiCharacterTest.txt against the bidirectional algorithm ucdx implements.`,
+		Args:  cobra.NoArgs,
+		RunE:  runConformanceBidi,
+	})
+}
+
+func runConformanceNormalization(cmd *cobra.Command, args []string) error {
+	err := conformanceFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	var cases []*parser.NormalizationTestCase
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+
+		f, err := db.OpenDataFile(appDirPath, ucd.TxtNormalizationTest)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cases, err = parser.ParseNormalizationTest(f)
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := normalize.NewNormalizer(u.UnicodeData, u.DerivedNormalizationProps)
+	if err != nil {
+		return err
+	}
+	result := conformance.RunNormalization(n, cases)
+
+	switch *conformanceFlags.output {
+	case "table":
+		for _, f := range result.Failures {
+			fmt.Println(f)
+		}
+		fmt.Printf("%v cases, %v failures\n", result.Cases, len(result.Failures))
+	case "json":
+		b, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("the normalization conformance test failed")
+	}
+	return nil
+}
+
+func runConformanceSegmentation(cmd *cobra.Command, args []string) error {
+	err := conformanceFlags.validate()
+	if err != nil {
+		return err
+	}
+	err = conformanceSegmentationFlags.validate()
+	if err != nil {
+		return err
+	}
+	kind := *conformanceSegmentationFlags.kind
+
+	var u *ucd.UCD
+	var cases []*parser.BreakTestCase
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+
+		f, err := db.OpenDataFile(appDirPath, segmentationTestFileNames[kind])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cases, err = parser.ParseBreakTest(f)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := newBreaker(u, kind)
+	if err != nil {
+		return err
+	}
+	result := conformance.RunSegmentation(b, cases)
+
+	switch *conformanceFlags.output {
+	case "table":
+		for _, f := range result.Failures {
+			fmt.Println(f)
+		}
+		fmt.Printf("%v: %v cases, %v passed, %v failed\n", kind, result.Cases, result.Passed, len(result.Failures))
+	case "json":
+		b, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("the %v segmentation conformance test failed", kind)
+	}
+	return nil
+}
+
+func runConformanceBidi(cmd *cobra.Command, args []string) error {
+	err := conformanceFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	var cases []*parser.BidiTestCase
+	var charCases []*parser.BidiCharacterTestCase
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+
+		f, err := db.OpenDataFile(appDirPath, ucd.TxtBidiTest)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cases, err = parser.ParseBidiTest(f)
+		if err != nil {
+			return err
+		}
+
+		g, err := db.OpenDataFile(appDirPath, ucd.TxtBidiCharacterTest)
+		if err != nil {
+			return err
+		}
+		defer g.Close()
+		charCases, err = parser.ParseBidiCharacterTest(g)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := conformance.RunBidi(cases)
+	if err != nil {
+		return err
+	}
+	charResult := conformance.RunBidiCharacter(bidi.NewResolver(u.UnicodeData, u.BidiBrackets, u.BidiMirroring), charCases)
+
+	switch *conformanceFlags.output {
+	case "table":
+		for _, f := range result.Failures {
+			fmt.Println(f)
+		}
+		for _, f := range charResult.Failures {
+			fmt.Println(f)
+		}
+		fmt.Printf("%v: %v cases, %v passed, %v failed\n", ucd.TxtBidiTest, result.Cases, result.Passed, len(result.Failures))
+		fmt.Printf("%v: %v cases, %v passed, %v failed\n", ucd.TxtBidiCharacterTest, charResult.Cases, charResult.Passed, len(charResult.Failures))
+	case "json":
+		b, err := json.Marshal(map[string]*conformance.BidiResult{
+			ucd.TxtBidiTest:          result,
+			ucd.TxtBidiCharacterTest: charResult,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	if len(result.Failures) > 0 || len(charResult.Failures) > 0 {
+		return fmt.Errorf("the bidi conformance test failed")
+	}
+	return nil
+}
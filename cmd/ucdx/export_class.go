@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/charclass"
+	"github.com/spf13/cobra"
+)
+
+type exportClassFlagSet struct {
+	flavor *string
+	utf16  *bool
+}
+
+func (f *exportClassFlagSet) validate() error {
+	_, err := charclass.ParseFlavor(*f.flavor)
+	if err != nil {
+		flavors := charclass.Flavors()
+		var b strings.Builder
+		fmt.Fprint(&b, flavors[0])
+		for _, fl := range flavors[1:] {
+			fmt.Fprint(&b, ", ", fl)
+		}
+		return fmt.Errorf("--flavor doesn't support %v, allowed values are: %v", *f.flavor, b.String())
+	}
+
+	return nil
+}
+
+var exportClassFlags = &exportClassFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "export-class <expression>",
+		Short: "Print code points selected by an expression as a character class of regular expressions",
+		Long: `export-class prints the code points an expression selects as a character class of a regular expression flavor.
+The expression is the same as the query command, such as gc=Nd or 'sc=Greek & gc=Lu'.
+The js flavor uses \u{...} escapes, which need the u flag. With --utf16, it prints a pattern matching UTF-16 code units
+instead, where supplementary code points are matched as surrogate pairs.
+The flavors matching UTF-8 text exclude the surrogate code points. The posix-bracket flavor consists of literal characters.`,
+		Example: `  ucdx export-class gc=Nd
+  ucdx export-class 'sc=Greek & gc=Lu' --flavor js
+  ucdx export-class Emoji --flavor js --utf16`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExportClass,
+	}
+	exportClassFlags.flavor = cmd.Flags().StringP("flavor", "f", "go", "Regular expression flavor. One of: go|re2|pcre|js|java|posix-bracket")
+	exportClassFlags.utf16 = cmd.Flags().Bool("utf16", false, "Match UTF-16 code units using surrogate pairs (js only)")
+	rootCmd.AddCommand(cmd)
+}
+
+func runExportClass(cmd *cobra.Command, args []string) error {
+	err := exportClassFlags.validate()
+	if err != nil {
+		return err
+	}
+	flavor, _ := charclass.ParseFlavor(*exportClassFlags.flavor)
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	cps, err := u.Query(args[0])
+	if err != nil {
+		return err
+	}
+
+	class, err := charclass.Format(cps, flavor, *exportClassFlags.utf16)
+	if err != nil {
+		return err
+	}
+	fmt.Println(class)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/spf13/cobra"
+)
+
+type exportJSONFlagSet struct {
+	output *string
+}
+
+var exportJSONFlags = &exportJSONFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "export-json",
+		Short: "Export the database in JSON format",
+		Long: `export-json exports the parsed data of each data file in the database to a JSON file such as UnicodeData.json.
+The names of the properties and their values are exported to unification.json.`,
+		Example: `  ucdx export-json -o ./ucd-json`,
+		Args:    cobra.NoArgs,
+		RunE:    runExportJSON,
+	}
+	exportJSONFlags.output = cmd.Flags().StringP("output", "o", ".", "Output directory path")
+	rootCmd.AddCommand(cmd)
+}
+
+func runExportJSON(cmd *cobra.Command, args []string) error {
+	homeDirPath, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+	return db.ExportJSON(appDirPath, *exportJSONFlags.output)
+}
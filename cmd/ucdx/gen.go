@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/gogen"
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/spf13/cobra"
+)
+
+type genGoFlagSet struct {
+	pkg    *string
+	vars   *[]string
+	output *string
+}
+
+func (f *genGoFlagSet) validate(args []string) error {
+	if *f.pkg == "" {
+		return fmt.Errorf("--package must be specified")
+	}
+	if len(*f.vars) > len(args) {
+		return fmt.Errorf("--var is specified %v times but there are %v expressions", len(*f.vars), len(args))
+	}
+
+	return nil
+}
+
+var genGoFlags = &genGoFlagSet{}
+
+type genTrieFlagSet struct {
+	pkg    *string
+	output *string
+}
+
+func (f *genTrieFlagSet) validate() error {
+	if *f.pkg == "" {
+		return fmt.Errorf("--package must be specified")
+	}
+
+	return nil
+}
+
+var genTrieFlags = &genTrieFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate source code of tables",
+		Long:  `gen generates source code of tables made from the UCD.`,
+	}
+	rootCmd.AddCommand(cmd)
+
+	goCmd := &cobra.Command{
+		Use:   "go <expression>...",
+		Short: "Generate Go source declaring *unicode.RangeTable variables",
+		Long: `go generates gofmt'd Go source declaring a *unicode.RangeTable variable for each expression.
+The expressions are the same as the query command, such as sc=Greek or a list of properties like White_Space.
+The n-th --var names the variable of the n-th expression. The variables without --var are named after their
+expressions, such as ScGreek for sc=Greek.`,
+		Example: `  ucdx gen go --package foo --var Greek 'sc=Greek'
+  ucdx gen go --package foo -o tables.go White_Space Dash 'gc=Nd & sc=Latin'
+
+  //go:generate ucdx gen go --package foo --var Greek -o greek.go sc=Greek`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runGenGo,
+	}
+	genGoFlags.pkg = goCmd.Flags().String("package", "", "Package name of the generated source")
+	genGoFlags.vars = goCmd.Flags().StringSlice("var", nil, "Variable name of each expression in order")
+	genGoFlags.output = goCmd.Flags().StringP("output", "o", "", "Output file path. The source is printed to stdout when omitted")
+	cmd.AddCommand(goCmd)
+
+	trieCmd := &cobra.Command{
+		Use:   "trie <property>",
+		Short: "Generate a Go package looking up a property with a two-stage trie",
+		Long: `trie generates gofmt'd Go source of a package looking up a property in constant time with a two-stage trie.
+The property must be a catalog, enumerated, or binary property, and may be specified by any of its aliases.
+The generated lookup.go has a Lookup(r rune) accessor returning the value, or whether the code point has a binary
+property. The generated lookup_test.go cross-checks every code point against the ranges of the values.`,
+		Example: `  ucdx gen trie --package script -o ./script sc
+  ucdx gen trie --package whitespace -o ./whitespace White_Space`,
+		Args: cobra.ExactArgs(1),
+		RunE: runGenTrie,
+	}
+	genTrieFlags.pkg = trieCmd.Flags().String("package", "", "Package name of the generated source")
+	genTrieFlags.output = trieCmd.Flags().StringP("output", "o", ".", "Output directory path")
+	cmd.AddCommand(trieCmd)
+}
+
+func runGenGo(cmd *cobra.Command, args []string) error {
+	err := genGoFlags.validate(args)
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	tables := make([]*gogen.RangeTable, len(args))
+	for i, expr := range args {
+		cps, err := u.Query(expr)
+		if err != nil {
+			return err
+		}
+		name := makeGoIdentifier(expr)
+		if i < len(*genGoFlags.vars) {
+			name = (*genGoFlags.vars)[i]
+		}
+		tables[i] = &gogen.RangeTable{
+			Name:   name,
+			Source: expr,
+			Ranges: cps,
+		}
+	}
+
+	src, err := gogen.GenerateRangeTables(*genGoFlags.pkg, tables)
+	if err != nil {
+		return err
+	}
+
+	if *genGoFlags.output == "" {
+		fmt.Print(string(src))
+		return nil
+	}
+	return ioutil.WriteFile(*genGoFlags.output, src, 0644)
+}
+
+func runGenTrie(cmd *cobra.Command, args []string) error {
+	err := genTrieFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	long, err := u.ResolvePropertyName(args[0])
+	if err != nil {
+		return err
+	}
+	meta := property.NewPropertyRegistry(u.PropertyAliases, u.PropertyValueAliases).Lookup(long)
+	if meta == nil {
+		return fmt.Errorf("unknown property: %v", args[0])
+	}
+	switch meta.Type {
+	case property.PropertyTypeCatalog, property.PropertyTypeEnumerated, property.PropertyTypeBinary:
+	default:
+		return fmt.Errorf("%v is a %v property; trie supports only catalog, enumerated, and binary properties", long, strings.ToLower(string(meta.Type)))
+	}
+
+	values := make([]string, unicode.MaxRune+1)
+	for c := range values {
+		v, err := u.Property(rune(c), long.String())
+		if err != nil {
+			return err
+		}
+		values[c] = v.String()
+	}
+
+	src, test, err := gogen.GenerateTrie(*genTrieFlags.pkg, &gogen.Trie{
+		Property: long.String(),
+		Binary:   meta.Type == property.PropertyTypeBinary,
+		Values:   values,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(*genTrieFlags.output, 0755)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(*genTrieFlags.output, "lookup.go"), src, 0644)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(*genTrieFlags.output, "lookup_test.go"), test, 0644)
+}
+
+// makeGoIdentifier makes an exported identifier from an expression by joining its alphanumeric words in title case,
+// such as `ScGreek` from `sc=Greek`.
+func makeGoIdentifier(expr string) string {
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(expr, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	}) {
+		r := []rune(w)
+		fmt.Fprint(&b, string(unicode.ToUpper(r[0]))+string(r[1:]))
+	}
+	return b.String()
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/segment"
+	"github.com/nihei9/ucdx/ucd/width"
+	"github.com/spf13/cobra"
+)
+
+var linebreakOutputSet = []string{
+	"table",
+	"json",
+}
+
+type linebreakFlagSet struct {
+	width     *int
+	ambiguous *string
+	output    *string
+}
+
+func (f *linebreakFlagSet) validate() error {
+	if *f.width < 0 {
+		return fmt.Errorf("--width must be 0 or more: %v", *f.width)
+	}
+
+	_, err := width.ParseAmbiguousPolicy(*f.ambiguous)
+	if err != nil {
+		return fmt.Errorf("--ambiguous doesn't support %v, allowed values are: narrow, wide", *f.ambiguous)
+	}
+
+	passed := false
+	for _, o := range linebreakOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, linebreakOutputSet[0])
+		for _, o := range linebreakOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var linebreakFlags = &linebreakFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "linebreak",
+		Short: "Show line break opportunities or wrap text",
+		Long: `linebreak shows the line break opportunities of text following UAX #14.
+Each segment ends with a mandatory or an allowed break.
+When --width is specified, linebreak wraps the text to the number of columns instead.
+The width of characters whose East_Asian_Width is Ambiguous follows --ambiguous.
+The text is read from the argument or the standard input.`,
+		Example: `  ucdx linebreak "The quick (\"brown\") fox can't jump 32.3 feet, right?"
+  cat README.md | ucdx linebreak --width 40`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runLinebreak,
+	}
+	linebreakFlags.width = cmd.Flags().IntP("width", "w", 0, "Wrap text to the number of columns. 0 means no wrapping")
+	linebreakFlags.ambiguous = cmd.Flags().StringP("ambiguous", "a", "narrow", "Width of ambiguous characters. One of: narrow|wide")
+	linebreakFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+type linebreakEntry struct {
+	Start int          `json:"start"`
+	End   int          `json:"end"`
+	Text  string       `json:"text"`
+	Break string       `json:"break"`
+	Rule  segment.Rule `json:"rule"`
+}
+
+func runLinebreak(cmd *cobra.Command, args []string) error {
+	err := linebreakFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := newBreaker(u, "line")
+	if err != nil {
+		return err
+	}
+
+	var it *segment.Iterator
+	if len(args) > 0 {
+		it = segment.NewIterator(b, []byte(args[0]))
+	} else {
+		it = segment.NewReaderIterator(b, os.Stdin)
+	}
+	var segs []*segment.Segment
+	for it.Next() {
+		segs = append(segs, it.Segment())
+	}
+	if it.Err() != nil {
+		return it.Err()
+	}
+
+	if *linebreakFlags.width > 0 {
+		policy, _ := width.ParseAmbiguousPolicy(*linebreakFlags.ambiguous)
+		lines := wrapLines(segs, *linebreakFlags.width, newWidthCalculator(u, policy).String)
+
+		switch *linebreakFlags.output {
+		case "table":
+			for _, l := range lines {
+				fmt.Println(l)
+			}
+		case "json":
+			if lines == nil {
+				lines = []string{}
+			}
+			b, err := json.Marshal(lines)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+		}
+		return nil
+	}
+
+	entries := make([]*linebreakEntry, len(segs))
+	for i, seg := range segs {
+		brk := "allowed"
+		if seg.Boundary.Mandatory {
+			brk = "mandatory"
+		}
+		entries[i] = &linebreakEntry{
+			Start: seg.Start,
+			End:   seg.End,
+			Text:  string(seg.Bytes),
+			Break: brk,
+			Rule:  seg.Boundary.Rule,
+		}
+	}
+
+	switch *linebreakFlags.output {
+	case "table":
+		fmt.Printf("%8v %8v  %-9v  %-5v  %v\n", "Start", "End", "Break", "Rule", "Segment")
+		for _, e := range entries {
+			fmt.Printf("%8v %8v  %-9v  %-5v  %q\n", e.Start, e.End, e.Break, e.Rule, e.Text)
+		}
+	case "json":
+		b, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
+
+// wrapLines wraps text consisting of segments between line break opportunities so that each line fits into the
+// width. `measure` returns the width of a string. A line ends at a mandatory break or at an allowed break before a
+// segment that overflows the line. Trailing white spaces don't count toward the width, and a segment wider than the
+// width occupies a line by itself.
+func wrapLines(segs []*segment.Segment, width int, measure func(s string) int) []string {
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	flush := func() {
+		lines = append(lines, strings.TrimRightFunc(line.String(), unicode.IsSpace))
+		line.Reset()
+		lineWidth = 0
+	}
+	for _, seg := range segs {
+		s := string(seg.Bytes)
+		if line.Len() > 0 && lineWidth+measure(strings.TrimRightFunc(s, unicode.IsSpace)) > width {
+			flush()
+		}
+		line.WriteString(s)
+		lineWidth += measure(s)
+		if seg.Boundary.Mandatory {
+			flush()
+		}
+	}
+	return lines
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/spf13/cobra"
+)
+
+var listOutputSet = []string{
+	"table",
+	"json",
+	"compact",
+}
+
+type listFlagSet struct {
+	output *string
+	expand *bool
+}
+
+func (f *listFlagSet) validate() error {
+	passed := false
+	for _, o := range listOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, listOutputSet[0])
+		for _, o := range listOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var listFlags = &listFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "list <property>=<value>",
+		Short: "List code points having a property value",
+		Long: `list lists code points having a property value.
+The property and the value may be any of their aliases, and they are matched loosely following UAX44-LM3.
+A binary property without a value means the code points having the property.
+The code points are printed as ranges, or one by one with their names when --expand is specified.
+The compact output prints the ranges in the notation of the data files such as 0030..0039.`,
+		Example: `  ucdx list gc=Nd
+  ucdx list White_Space
+  ucdx list --expand sc=Grek`,
+		Args: cobra.ExactArgs(1),
+		RunE: runList,
+	}
+	listFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table|compact")
+	listFlags.expand = cmd.Flags().BoolP("expand", "e", false, "Print each code point with its name instead of ranges")
+	rootCmd.AddCommand(cmd)
+}
+
+type listRange struct {
+	From  rune `json:"from"`
+	To    rune `json:"to"`
+	Count int  `json:"count"`
+}
+
+type listCodePoint struct {
+	CP   rune   `json:"code_point"`
+	Name string `json:"name"`
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	err := listFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	name, value := parsePropertyValue(args[0])
+	cps, err := u.CodePointRanges(name, value)
+	if err != nil {
+		return err
+	}
+
+	return printCodePointRanges(u, cps, *listFlags.output, *listFlags.expand)
+}
+
+// printCodePointRanges prints code point ranges in an output format. When `expand` is true, each code point is printed
+// with its name instead of the ranges.
+func printCodePointRanges(u *ucd.UCD, cps []*property.CodePointRange, output string, expand bool) error {
+	if output == "compact" {
+		for _, cp := range cps {
+			fmt.Println(formatCodePointRange(cp))
+		}
+		return nil
+	}
+
+	if expand {
+		var chars []*listCodePoint
+		for _, cp := range cps {
+			from, to := cp.Range()
+			for c := from; c <= to; c++ {
+				na, err := u.Property(c, property.PropNameName.String())
+				if err != nil {
+					return err
+				}
+				chars = append(chars, &listCodePoint{
+					CP:   c,
+					Name: na.String(),
+				})
+			}
+		}
+
+		switch output {
+		case "table":
+			fmt.Printf("%-10v  %-4v  %v\n", "Code Point", "Char", "Name")
+			for _, c := range chars {
+				fmt.Printf("%-10v  %-4q  %v\n", fmt.Sprintf("U+%04X", c.CP), c.CP, c.Name)
+			}
+		case "json":
+			b, err := json.Marshal(chars)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+		}
+		return nil
+	}
+
+	ranges := make([]*listRange, len(cps))
+	total := 0
+	for i, cp := range cps {
+		from, to := cp.Range()
+		ranges[i] = &listRange{
+			From:  from,
+			To:    to,
+			Count: int(to-from) + 1,
+		}
+		total += ranges[i].Count
+	}
+
+	switch output {
+	case "table":
+		fmt.Printf("%-14v  %v\n", "Range", "Count")
+		for _, r := range ranges {
+			fmt.Printf("%-14v  %v\n", formatCodePointRange(property.NewCodePointRange(r.From, r.To)), r.Count)
+		}
+		fmt.Printf("Total: %v code points in %v ranges\n", total, len(ranges))
+	case "json":
+		b, err := json.Marshal(ranges)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
+
+// parsePropertyValue splits an expression like `gc=Nd` into a property and a value. An expression without a value
+// means a binary property is true.
+func parsePropertyValue(expr string) (string, string) {
+	i := strings.Index(expr, "=")
+	if i < 0 {
+		return strings.TrimSpace(expr), "Yes"
+	}
+	return strings.TrimSpace(expr[:i]), strings.TrimSpace(expr[i+1:])
+}
+
+// formatCodePointRange formats a code point range in the notation of the data files, such as `0030..0039`.
+func formatCodePointRange(cp *property.CodePointRange) string {
+	from, to := cp.Range()
+	if from == to {
+		return fmt.Sprintf("%04X", from)
+	}
+	return fmt.Sprintf("%04X..%04X", from, to)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/nihei9/ucdx/db"
 	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/width"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +20,8 @@ var lookupOutputSet = []string{
 }
 
 type lookupFlagSet struct {
-	output *string
+	output     *string
+	properties *[]string
 }
 
 func (f *lookupFlagSet) validate() error {
@@ -54,6 +56,7 @@ func init() {
 		RunE:  runLookup,
 	}
 	lookupFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	lookupFlags.properties = cmd.Flags().StringSliceP("properties", "p", nil, "Properties to print. The default properties are printed by default")
 	rootCmd.AddCommand(cmd)
 }
 
@@ -77,6 +80,11 @@ func runLookup(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	names, err := selectProperties(u, *lookupFlags.properties)
+	if err != nil {
+		return err
+	}
+
 	n, err := strconv.ParseInt(args[0], 16, 32)
 	if err != nil {
 		return fmt.Errorf("invalid code point: %v", err)
@@ -86,11 +94,14 @@ func runLookup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%X is an invalid code point. A code point must be in the range of U+0000 to U+10FFFF.", c)
 	}
 
-	result := u.AnalizeCodePoint(c)
+	result, err := u.AnalizeCodePointProperties(c, names)
+	if err != nil {
+		return err
+	}
 
 	switch *lookupFlags.output {
 	case "table":
-		printPropertySetAsTable([]*ucd.PropertySet{result})
+		printPropertySetAsTable([]*ucd.PropertySet{result}, names, newWidthCalculator(u, width.AmbiguousNarrow))
 	case "json":
 		b, err := json.Marshal(result)
 		if err != nil {
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/normalize"
+	"github.com/spf13/cobra"
+)
+
+type normalizeFlagSet struct {
+	form *string
+}
+
+func (f *normalizeFlagSet) validate() error {
+	_, err := normalize.ParseForm(*f.form)
+	if err != nil {
+		return fmt.Errorf("--form doesn't support %v, allowed values are: nfc, nfd, nfkc, nfkd", *f.form)
+	}
+
+	return nil
+}
+
+var normalizeFlags = &normalizeFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "normalize",
+		Short: "Convert text into a normalization form",
+		Long: `normalize converts text into a normalization form.
+The text is read from the argument or the standard input.`,
+		Example: `  ucdx normalize --form nfd Å
+  echo 'ｱｲｳｴｵ' | ucdx normalize --form nfkc`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runNormalize,
+	}
+	normalizeFlags.form = cmd.Flags().StringP("form", "f", "nfc", "Normalization form. One of: nfc|nfd|nfkc|nfkd")
+	rootCmd.AddCommand(cmd)
+}
+
+func runNormalize(cmd *cobra.Command, args []string) error {
+	err := normalizeFlags.validate()
+	if err != nil {
+		return err
+	}
+	form, _ := normalize.ParseForm(*normalizeFlags.form)
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := normalize.NewNormalizer(u.UnicodeData, u.DerivedNormalizationProps)
+	if err != nil {
+		return err
+	}
+
+	var src io.Reader
+	if len(args) > 0 {
+		src = strings.NewReader(args[0])
+	} else {
+		src = os.Stdin
+	}
+	b, err := ioutil.ReadAll(src)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(n.String(form, string(b)))
+	if len(args) > 0 {
+		fmt.Print("\n")
+	}
+
+	return nil
+}
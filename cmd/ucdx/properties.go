@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/spf13/cobra"
+)
+
+var propertiesOutputSet = []string{
+	"table",
+	"json",
+}
+
+type propertiesFlagSet struct {
+	output *string
+}
+
+func (f *propertiesFlagSet) validate() error {
+	passed := false
+	for _, o := range propertiesOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, propertiesOutputSet[0])
+		for _, o := range propertiesOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var propertiesFlags = &propertiesFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "properties",
+		Short: "List the properties of the UCD",
+		Long: `properties lists the properties PropertyAliases.txt defines with their names, types, source files, and default values.
+The Loaded column shows whether ucdx can look up the property.`,
+		Example: `  ucdx properties
+  ucdx properties -o json`,
+		Args: cobra.NoArgs,
+		RunE: runProperties,
+	}
+	propertiesFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+type propertyEntry struct {
+	*property.PropertyMetadata
+	Loaded bool `json:"loaded"`
+}
+
+func runProperties(cmd *cobra.Command, args []string) error {
+	err := propertiesFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	reg := property.NewPropertyRegistry(u.PropertyAliases, u.PropertyValueAliases)
+	entries := make([]*propertyEntry, len(reg.Properties))
+	for i, p := range reg.Properties {
+		entries[i] = &propertyEntry{
+			PropertyMetadata: p,
+			Loaded:           u.PropertyLoaded(p.Long),
+		}
+	}
+
+	switch *propertiesFlags.output {
+	case "table":
+		abbWidth := len("Short")
+		longWidth := len("Long")
+		for _, e := range entries {
+			if len(e.Abb) > abbWidth {
+				abbWidth = len(e.Abb)
+			}
+			if len(e.Long) > longWidth {
+				longWidth = len(e.Long)
+			}
+		}
+		fmt.Printf("%-*v  %-*v  %-13v  %-34v  %-14v  %v\n", abbWidth, "Short", longWidth, "Long", "Type", "Source", "Default", "Loaded")
+		for _, e := range entries {
+			loaded := "No"
+			if e.Loaded {
+				loaded = "Yes"
+			}
+			fmt.Printf("%-*v  %-*v  %-13v  %-34v  %-14v  %v\n", abbWidth, e.Abb, longWidth, e.Long, e.Type, strings.Join(e.Files, ", "), e.Default, loaded)
+		}
+	case "json":
+		b, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/spf13/cobra"
+)
+
+type queryFlagSet struct {
+	output *string
+	expand *bool
+}
+
+func (f *queryFlagSet) validate() error {
+	passed := false
+	for _, o := range listOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, listOutputSet[0])
+		for _, o := range listOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var queryFlags = &queryFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "query <expression>",
+		Short: "Select code points by an expression over property values",
+		Long: `query selects code points by an expression combining terms like sc=Greek with set operators.
+The operators are ! (complement), & (intersection), - (difference), and | (union) in descending order of precedence.
+Parentheses group subexpressions. A term without a value such as White_Space means a binary property is true.
+A hyphen is a difference operator only when it follows a space or ), so terms like gc=Decimal-Number work.
+The output formats are the same as the list command.`,
+		Example: `  ucdx query 'sc=Greek & gc=Lu & !Deprecated'
+  ucdx query '(gc=Nd | gc=Nl) - sc=Common' -o compact`,
+		Args: cobra.ExactArgs(1),
+		RunE: runQuery,
+	}
+	queryFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table|compact")
+	queryFlags.expand = cmd.Flags().BoolP("expand", "e", false, "Print each code point with its name instead of ranges")
+	rootCmd.AddCommand(cmd)
+}
+
+func runQuery(cmd *cobra.Command, args []string) error {
+	err := queryFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	cps, err := u.Query(args[0])
+	if err != nil {
+		return err
+	}
+
+	return printCodePointRanges(u, cps, *queryFlags.output, *queryFlags.expand)
+}
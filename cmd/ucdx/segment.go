@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/segment"
+	"github.com/spf13/cobra"
+)
+
+var segmentUnitSet = []string{
+	"grapheme",
+	"word",
+	"sentence",
+}
+
+var segmentOutputSet = []string{
+	"table",
+	"json",
+}
+
+type segmentFlagSet struct {
+	unit   *string
+	output *string
+}
+
+func (f *segmentFlagSet) validate() error {
+	passed := false
+	for _, u := range segmentUnitSet {
+		if *f.unit == u {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, segmentUnitSet[0])
+		for _, v := range segmentUnitSet[1:] {
+			fmt.Fprint(&b, ", ", v)
+		}
+		return fmt.Errorf("--unit doesn't support %v, allowed values are: %v", *f.unit, b.String())
+	}
+
+	passed = false
+	for _, o := range segmentOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, segmentOutputSet[0])
+		for _, v := range segmentOutputSet[1:] {
+			fmt.Fprint(&b, ", ", v)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var segmentFlags = &segmentFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "segment",
+		Short: "Split text into grapheme clusters, words, or sentences",
+		Long: `segment splits text into grapheme clusters, words, or sentences following UAX #29,
+and prints each segment with its byte offsets.
+The text is read from the argument or the standard input.`,
+		Example: `  ucdx segment --unit word "Hello, world."
+  cat README.md | ucdx segment --unit sentence --output json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runSegment,
+	}
+	segmentFlags.unit = cmd.Flags().StringP("unit", "u", "word", "Unit of segments. One of: grapheme|word|sentence")
+	segmentFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+type segmentEntry struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+}
+
+func runSegment(cmd *cobra.Command, args []string) error {
+	err := segmentFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := newBreaker(u, *segmentFlags.unit)
+	if err != nil {
+		return err
+	}
+
+	var it *segment.Iterator
+	if len(args) > 0 {
+		it = segment.NewIterator(b, []byte(args[0]))
+	} else {
+		it = segment.NewReaderIterator(b, os.Stdin)
+	}
+
+	switch *segmentFlags.output {
+	case "table":
+		fmt.Printf("%8v %8v  %v\n", "Start", "End", "Segment")
+		for it.Next() {
+			seg := it.Segment()
+			fmt.Printf("%8v %8v  %q\n", seg.Start, seg.End, seg.Bytes)
+		}
+	case "json":
+		entries := []*segmentEntry{}
+		for it.Next() {
+			seg := it.Segment()
+			entries = append(entries, &segmentEntry{
+				Start: seg.Start,
+				End:   seg.End,
+				Text:  string(seg.Bytes),
+			})
+		}
+		if it.Err() != nil {
+			return it.Err()
+		}
+		b, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return it.Err()
+}
+
+// newBreaker returns a breaker for a kind of segmentation.
+func newBreaker(u *ucd.UCD, kind string) (segment.Breaker, error) {
+	switch kind {
+	case "grapheme":
+		return segment.NewGraphemeBreaker(u.GraphemeBreakProperty, u.EmojiData), nil
+	case "word":
+		return segment.NewWordBreaker(u.WordBreakProperty, u.EmojiData), nil
+	case "sentence":
+		return segment.NewSentenceBreaker(u.SentenceBreakProperty), nil
+	case "line":
+		return segment.NewLineBreaker(u.LineBreak, u.EastAsianWidth, u.EmojiData, u.UnicodeData), nil
+	}
+	return nil, fmt.Errorf("ucdx doesn't support the %v segmentation yet", kind)
+}
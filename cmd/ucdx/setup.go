@@ -12,9 +12,10 @@ func init() {
 	cmd := &cobra.Command{
 		Use:   "setup",
 		Short: "Set up the database ucdx refereces",
-		Long:  `setup downloads the UCD's data files and parses them. The parsed data files are saved to the ${HOME}/.ucdx directory in JSON format.`,
-		Args:  cobra.NoArgs,
-		RunE:  runSetup,
+		Long: `setup downloads the UCD's data files and parses them. The parsed data are saved to the ${HOME}/.ucdx directory as a
+single database file in a binary format. The export-json command exports them in JSON format.`,
+		Args: cobra.NoArgs,
+		RunE: runSetup,
 	}
 	rootCmd.AddCommand(cmd)
 }
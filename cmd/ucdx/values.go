@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/spf13/cobra"
+)
+
+var valuesOutputSet = []string{
+	"table",
+	"json",
+}
+
+type valuesFlagSet struct {
+	output *string
+}
+
+func (f *valuesFlagSet) validate() error {
+	passed := false
+	for _, o := range valuesOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, valuesOutputSet[0])
+		for _, o := range valuesOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var valuesFlags = &valuesFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "values <property>",
+		Short: "List the values of a property",
+		Long: `values lists the values of a property with their aliases PropertyValueAliases.txt defines.
+The property may be specified by any of its aliases.
+When ucdx loads the property, the number of code points having each value is printed too.`,
+		Example: `  ucdx values gc
+  ucdx values Script -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runValues,
+	}
+	valuesFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+// propertyValue is a value of a property. `Count` is nil when ucdx doesn't load the property.
+type propertyValue struct {
+	Abb    property.PropertyValueSymbol   `json:"abb"`
+	Long   property.PropertyValueSymbol   `json:"long"`
+	Others []property.PropertyValueSymbol `json:"others"`
+	Count  *int                           `json:"count,omitempty"`
+}
+
+func runValues(cmd *cobra.Command, args []string) error {
+	err := valuesFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	long, err := u.ResolvePropertyName(args[0])
+	if err != nil {
+		return err
+	}
+	meta := property.NewPropertyRegistry(u.PropertyAliases, u.PropertyValueAliases).Lookup(long)
+	if meta == nil {
+		return fmt.Errorf("unknown property: %v", args[0])
+	}
+	switch meta.Type {
+	case property.PropertyTypeCatalog, property.PropertyTypeEnumerated, property.PropertyTypeBinary:
+	default:
+		return fmt.Errorf("%v is a %v property; values supports only catalog, enumerated, and binary properties", long, strings.ToLower(string(meta.Type)))
+	}
+	abb := meta.Abb
+
+	var values []*propertyValue
+	for _, a := range u.PropertyValueAliases.Aliases[abb] {
+		values = append(values, &propertyValue{
+			Abb:    a.Abb,
+			Long:   a.Long,
+			Others: a.Others,
+		})
+	}
+
+	if u.PropertyLoaded(long) {
+		ranges, err := u.PropertyValueRanges(long.String())
+		if err != nil {
+			return err
+		}
+		counts := map[property.PropertyValueSymbol]int{}
+		for v, cps := range ranges {
+			n := 0
+			for _, cp := range cps {
+				from, to := cp.Range()
+				n += int(to-from) + 1
+			}
+			counts[property.NormalizeSymbol(v)] = n
+		}
+		for _, v := range values {
+			n := countPropertyValue(counts, v)
+			v.Count = &n
+		}
+		// Values missing from PropertyValueAliases.txt, such as ones of properties newer than the file, are listed
+		// by the names the data files use.
+		var extras []*propertyValue
+		for sym, n := range counts {
+			n := n
+			extras = append(extras, &propertyValue{
+				Abb:   sym,
+				Long:  sym,
+				Count: &n,
+			})
+		}
+		sort.Slice(extras, func(i, j int) bool {
+			return extras[i].Long < extras[j].Long
+		})
+		values = append(values, extras...)
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("%v has no values ucdx knows", long)
+	}
+
+	switch *valuesFlags.output {
+	case "table":
+		fmt.Printf("%-12v  %-40v  %-20v  %v\n", "Short", "Long", "Others", "Count")
+		for _, v := range values {
+			var others strings.Builder
+			for i, o := range v.Others {
+				if i > 0 {
+					fmt.Fprint(&others, ", ")
+				}
+				fmt.Fprint(&others, o)
+			}
+			count := "-"
+			if v.Count != nil {
+				count = fmt.Sprint(*v.Count)
+			}
+			fmt.Printf("%-12v  %-40v  %-20v  %v\n", v.Abb, v.Long, others.String(), count)
+		}
+	case "json":
+		b, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
+
+// countPropertyValue returns the number of code points having a value, and removes the value from `counts` so that
+// the remaining ones are the values PropertyValueAliases.txt doesn't define.
+func countPropertyValue(counts map[property.PropertyValueSymbol]int, v *propertyValue) int {
+	syms := append([]property.PropertyValueSymbol{v.Abb, v.Long}, v.Others...)
+	for _, sym := range syms {
+		if n, ok := counts[sym]; ok {
+			delete(counts, sym)
+			return n
+		}
+	}
+	return 0
+}
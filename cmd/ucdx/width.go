@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/width"
+	"github.com/spf13/cobra"
+)
+
+var widthOutputSet = []string{
+	"table",
+	"json",
+}
+
+type widthFlagSet struct {
+	ambiguous *string
+	output    *string
+}
+
+func (f *widthFlagSet) validate() error {
+	_, err := width.ParseAmbiguousPolicy(*f.ambiguous)
+	if err != nil {
+		return fmt.Errorf("--ambiguous doesn't support %v, allowed values are: narrow, wide", *f.ambiguous)
+	}
+
+	passed := false
+	for _, o := range widthOutputSet {
+		if *f.output == o {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		var b strings.Builder
+		fmt.Fprint(&b, widthOutputSet[0])
+		for _, o := range widthOutputSet[1:] {
+			fmt.Fprint(&b, ", ", o)
+		}
+		return fmt.Errorf("--output doesn't support %v, allowed values are: %v", *f.output, b.String())
+	}
+
+	return nil
+}
+
+var widthFlags = &widthFlagSet{}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "width",
+		Short: "Calculate the display width of text",
+		Long: `width calculates the number of columns text occupies in terminals.
+It shows the width of each grapheme cluster and the total width.
+The width of characters whose East_Asian_Width is Ambiguous follows --ambiguous.
+The text is read from the argument or the standard input.`,
+		Example: `  ucdx width 'こんにちは, world'
+  ucdx width --ambiguous wide '±1°'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runWidth,
+	}
+	widthFlags.ambiguous = cmd.Flags().StringP("ambiguous", "a", "narrow", "Width of ambiguous characters. One of: narrow|wide")
+	widthFlags.output = cmd.Flags().StringP("output", "o", "table", "Output format. One of: json|table")
+	rootCmd.AddCommand(cmd)
+}
+
+type widthCluster struct {
+	Cluster    string   `json:"cluster"`
+	CodePoints []string `json:"code_points"`
+	Width      int      `json:"width"`
+}
+
+type widthResult struct {
+	Width    int             `json:"width"`
+	Clusters []*widthCluster `json:"clusters"`
+}
+
+func runWidth(cmd *cobra.Command, args []string) error {
+	err := widthFlags.validate()
+	if err != nil {
+		return err
+	}
+	policy, _ := width.ParseAmbiguousPolicy(*widthFlags.ambiguous)
+
+	var u *ucd.UCD
+	{
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		appDirPath := filepath.Join(homeDirPath, ".ucdx")
+
+		u, err = db.OpenDB(appDirPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var src io.Reader
+	if len(args) > 0 {
+		src = strings.NewReader(args[0])
+	} else {
+		src = os.Stdin
+	}
+	b, err := ioutil.ReadAll(src)
+	if err != nil {
+		return err
+	}
+
+	wc := newWidthCalculator(u, policy)
+	clusters, ws := wc.Clusters([]rune(string(b)))
+	result := &widthResult{
+		Clusters: make([]*widthCluster, len(clusters)),
+	}
+	for i, cluster := range clusters {
+		cps := make([]string, len(cluster))
+		for j, c := range cluster {
+			cps[j] = fmt.Sprintf("U+%X", c)
+		}
+		result.Clusters[i] = &widthCluster{
+			Cluster:    string(cluster),
+			CodePoints: cps,
+			Width:      ws[i],
+		}
+		result.Width += ws[i]
+	}
+
+	switch *widthFlags.output {
+	case "table":
+		fmt.Printf("%5v  %v  %v\n", "Width", padColumn(wc, "Cluster", 8), "Code Points")
+		for _, c := range result.Clusters {
+			fmt.Printf("%5v  %v  %v\n", c.Width, padColumn(wc, c.Cluster, 8), strings.Join(c.CodePoints, " "))
+		}
+		fmt.Printf("Total: %v\n", result.Width)
+	case "json":
+		b, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	}
+
+	return nil
+}
+
+func newWidthCalculator(u *ucd.UCD, policy width.AmbiguousPolicy) *width.Calculator {
+	return width.NewCalculator(u.EastAsianWidth, u.UnicodeData, u.GraphemeBreakProperty, u.EmojiData, policy)
+}
+
+// padColumn pads a string with spaces so that it occupies `n` columns in terminals.
+func padColumn(wc *width.Calculator, s string, n int) string {
+	w := wc.String(s)
+	if w >= n {
+		return s
+	}
+	return s + strings.Repeat(" ", n-w)
+}
@@ -26,6 +26,31 @@ func MakeDB(config *DBConfig) error {
 		ucd.TxtPropertyAliases,
 		ucd.TxtPropertyValueAliases,
 		ucd.TxtPropList,
+		ucd.TxtScripts,
+		ucd.TxtScriptExtensions,
+		ucd.TxtBlocks,
+		ucd.TxtDerivedNormalizationProps,
+		ucd.TxtLineBreak,
+		ucd.TxtEastAsianWidth,
+		ucd.TxtBidiBrackets,
+		ucd.TxtBidiMirroring,
+		ucd.TxtCaseFolding,
+		ucd.TxtSpecialCasing,
+		ucd.TxtGraphemeBreakProperty,
+		ucd.TxtWordBreakProperty,
+		ucd.TxtSentenceBreakProperty,
+		ucd.TxtEmojiData,
+	}
+
+	// Test data files are only downloaded because they are used for conformance testing as they are.
+	testDataFileNames := []string{
+		ucd.TxtNormalizationTest,
+		ucd.TxtGraphemeBreakTest,
+		ucd.TxtWordBreakTest,
+		ucd.TxtSentenceBreakTest,
+		ucd.TxtLineBreakTest,
+		ucd.TxtBidiTest,
+		ucd.TxtBidiCharacterTest,
 	}
 
 	tempDirPath, err := os.MkdirTemp(config.AppDirPath, "db-*")
@@ -33,56 +58,29 @@ func MakeDB(config *DBConfig) error {
 		return err
 	}
 
-	for _, dataFileName := range dataFileNames {
+	for _, dataFileName := range append(dataFileNames, testDataFileNames...) {
 		err := fetchDataFile(dataFileName, tempDirPath)
 		if err != nil {
 			return err
 		}
 	}
 
+	sections := map[string]interface{}{}
 	for _, dataFileName := range dataFileNames {
-		err := parseDataFile(tempDirPath, dataFileName)
+		data, err := parseDataFile(tempDirPath, dataFileName)
 		if err != nil {
 			return err
 		}
+		sections[dataFileName] = data
 	}
+	sections[sectionUnification] = property.NewUnification(
+		sections[ucd.TxtPropertyAliases].(*property.PropertyAliases),
+		sections[ucd.TxtPropertyValueAliases].(*property.PropertyValueAliases),
+	)
 
-	{
-		var propAliases *property.PropertyAliases
-		{
-			b, err := ioutil.ReadFile(filepath.Join(tempDirPath, makeParsedDataFileName(ucd.TxtPropertyAliases)))
-			if err != nil {
-				return err
-			}
-			propAliases = &property.PropertyAliases{}
-			err = json.Unmarshal(b, propAliases)
-			if err != nil {
-				return err
-			}
-		}
-
-		var propValAliases *property.PropertyValueAliases
-		{
-			b, err := ioutil.ReadFile(filepath.Join(tempDirPath, makeParsedDataFileName(ucd.TxtPropertyValueAliases)))
-			if err != nil {
-				return err
-			}
-			propValAliases = &property.PropertyValueAliases{}
-			err = json.Unmarshal(b, propValAliases)
-			if err != nil {
-				return err
-			}
-		}
-
-		uni := property.NewUnification(propAliases, propValAliases)
-		b, err := json.Marshal(uni)
-		if err != nil {
-			return err
-		}
-		err = ioutil.WriteFile(filepath.Join(tempDirPath, "unification.json"), b, 0644)
-		if err != nil {
-			return err
-		}
+	err = writeDBFile(filepath.Join(tempDirPath, dbFileName), sections)
+	if err != nil {
+		return err
 	}
 
 	dbDirPath := filepath.Join(config.AppDirPath, "db")
@@ -109,13 +107,19 @@ func fetchDataFile(dataFileName string, dirPath string) error {
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(filepath.Join(dirPath, dataFileName), d, 0644)
+	filePath := filepath.Join(dirPath, dataFileName)
+	err = os.MkdirAll(filepath.Dir(filePath), 0744)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, d, 0644)
 }
 
-func parseDataFile(dirPath string, dataFileName string) error {
+// parseDataFile parses a data file and returns the parsed data.
+func parseDataFile(dirPath string, dataFileName string) (interface{}, error) {
 	f, err := os.Open(filepath.Join(dirPath, dataFileName))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer f.Close()
 
@@ -133,125 +137,119 @@ func parseDataFile(dirPath string, dataFileName string) error {
 		data, err = parser.ParsePropertyValueAliases(f)
 	case ucd.TxtPropList:
 		data, err = parser.ParsePropList(f)
+	case ucd.TxtScripts:
+		data, err = parser.ParseScripts(f)
+	case ucd.TxtScriptExtensions:
+		data, err = parser.ParseScriptExtensions(f)
+	case ucd.TxtBlocks:
+		data, err = parser.ParseBlocks(f)
+	case ucd.TxtDerivedNormalizationProps:
+		data, err = parser.ParseDerivedNormalizationProps(f)
+	case ucd.TxtLineBreak:
+		data, err = parser.ParseLineBreak(f)
+	case ucd.TxtEastAsianWidth:
+		data, err = parser.ParseEastAsianWidth(f)
+	case ucd.TxtBidiBrackets:
+		data, err = parser.ParseBidiBrackets(f)
+	case ucd.TxtBidiMirroring:
+		data, err = parser.ParseBidiMirroring(f)
+	case ucd.TxtCaseFolding:
+		data, err = parser.ParseCaseFolding(f)
+	case ucd.TxtSpecialCasing:
+		data, err = parser.ParseSpecialCasing(f)
+	case ucd.TxtGraphemeBreakProperty:
+		data, err = parser.ParseGraphemeBreakProperty(f)
+	case ucd.TxtWordBreakProperty:
+		data, err = parser.ParseWordBreakProperty(f)
+	case ucd.TxtSentenceBreakProperty:
+		data, err = parser.ParseSentenceBreakProperty(f)
+	case ucd.TxtEmojiData:
+		data, err = parser.ParseEmojiData(f)
 	default:
-		return fmt.Errorf("unknown data file name: %v", dataFileName)
+		return nil, fmt.Errorf("unknown data file name: %v", dataFileName)
 	}
 	if err != nil {
-		return err
-	}
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
+		return nil, err
 	}
-	jsonFileName := fmt.Sprintf("%v.json", strings.TrimSuffix(dataFileName, ".txt"))
-	return ioutil.WriteFile(filepath.Join(dirPath, jsonFileName), jsonData, 0644)
+	return data, nil
 }
 
+// OpenDB opens the database MakeDB made. It returns an error telling to rebuild the database when the database has
+// a format or a Unicode version other than the ones ucdx supports.
 func OpenDB(appDirPath string) (*ucd.UCD, error) {
-	var ud *property.UnicodeData
-	{
-		d, err := os.ReadFile(makeParsedDataFilePath(appDirPath, ucd.TxtUnicodeData))
-		if err != nil {
-			return nil, err
-		}
-		ud = &property.UnicodeData{}
-		err = json.Unmarshal(d, ud)
-		if err != nil {
-			return nil, err
-		}
+	f, err := readDBFile(filepath.Join(appDirPath, "db", dbFileName))
+	if err != nil {
+		return nil, err
 	}
-
-	var nameAliases *property.NameAliases
-	{
-		d, err := os.ReadFile(makeParsedDataFilePath(appDirPath, ucd.TxtNameAliases))
-		if err != nil {
-			return nil, err
-		}
-		nameAliases = &property.NameAliases{}
-		err = json.Unmarshal(d, nameAliases)
+	sections := map[string]interface{}{}
+	for _, name := range dbSectionNames {
+		data, err := f.decode(name)
 		if err != nil {
 			return nil, err
 		}
+		sections[name] = data
 	}
 
-	var derivedCoreProps *property.DerivedCoreProperties
-	{
-		d, err := os.ReadFile(makeParsedDataFilePath(appDirPath, ucd.TxtDerivedCoreProperties))
-		if err != nil {
-			return nil, err
-		}
-		derivedCoreProps = &property.DerivedCoreProperties{}
-		err = json.Unmarshal(d, derivedCoreProps)
-		if err != nil {
-			return nil, err
-		}
+	u := &ucd.UCD{
+		UnicodeData:               sections[ucd.TxtUnicodeData].(*property.UnicodeData),
+		NameAliases:               sections[ucd.TxtNameAliases].(*property.NameAliases),
+		DerivedCoreProperties:     sections[ucd.TxtDerivedCoreProperties].(*property.DerivedCoreProperties),
+		PropertyAliases:           sections[ucd.TxtPropertyAliases].(*property.PropertyAliases),
+		PropertyValueAliases:      sections[ucd.TxtPropertyValueAliases].(*property.PropertyValueAliases),
+		PropList:                  sections[ucd.TxtPropList].(*property.PropList),
+		Scripts:                   sections[ucd.TxtScripts].(*property.Scripts),
+		ScriptExtensions:          sections[ucd.TxtScriptExtensions].(*property.ScriptExtensions),
+		Blocks:                    sections[ucd.TxtBlocks].(*property.Blocks),
+		DerivedNormalizationProps: sections[ucd.TxtDerivedNormalizationProps].(*property.DerivedNormalizationProps),
+		LineBreak:                 sections[ucd.TxtLineBreak].(*property.LineBreak),
+		EastAsianWidth:            sections[ucd.TxtEastAsianWidth].(*property.EastAsianWidth),
+		BidiBrackets:              sections[ucd.TxtBidiBrackets].(*property.BidiBrackets),
+		BidiMirroring:             sections[ucd.TxtBidiMirroring].(*property.BidiMirroring),
+		CaseFolding:               sections[ucd.TxtCaseFolding].(*property.CaseFolding),
+		SpecialCasing:             sections[ucd.TxtSpecialCasing].(*property.SpecialCasing),
+		GraphemeBreakProperty:     sections[ucd.TxtGraphemeBreakProperty].(*property.GraphemeBreakProperty),
+		WordBreakProperty:         sections[ucd.TxtWordBreakProperty].(*property.WordBreakProperty),
+		SentenceBreakProperty:     sections[ucd.TxtSentenceBreakProperty].(*property.SentenceBreakProperty),
+		EmojiData:                 sections[ucd.TxtEmojiData].(*property.EmojiData),
+		Unification:               sections[sectionUnification].(*property.Unification),
 	}
+	u.BuildIndex()
 
-	var propAliases *property.PropertyAliases
-	{
-		d, err := os.ReadFile(makeParsedDataFilePath(appDirPath, ucd.TxtPropertyAliases))
-		if err != nil {
-			return nil, err
-		}
-		propAliases = &property.PropertyAliases{}
-		err = json.Unmarshal(d, propAliases)
-		if err != nil {
-			return nil, err
-		}
-	}
+	return u, nil
+}
 
-	var propValAliases *property.PropertyValueAliases
-	{
-		d, err := os.ReadFile(makeParsedDataFilePath(appDirPath, ucd.TxtPropertyValueAliases))
-		if err != nil {
-			return nil, err
-		}
-		propValAliases = &property.PropertyValueAliases{}
-		err = json.Unmarshal(d, propValAliases)
-		if err != nil {
-			return nil, err
-		}
+// ExportJSON writes the parsed data of each data file in the database to a JSON file in a directory, such as
+// UnicodeData.json. The data of the property.Unification is written to unification.json.
+func ExportJSON(appDirPath string, outDirPath string) error {
+	f, err := readDBFile(filepath.Join(appDirPath, "db", dbFileName))
+	if err != nil {
+		return err
 	}
-
-	var propList *property.PropList
-	{
-		d, err := os.ReadFile(makeParsedDataFilePath(appDirPath, ucd.TxtPropList))
+	for _, name := range dbSectionNames {
+		data, err := f.decode(name)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		propList = &property.PropList{}
-		err = json.Unmarshal(d, propList)
+		b, err := json.Marshal(data)
 		if err != nil {
-			return nil, err
+			return err
 		}
-	}
-
-	var unification *property.Unification
-	{
-		d, err := ioutil.ReadFile(filepath.Join(appDirPath, "db", "unification.json"))
+		filePath := filepath.Join(outDirPath, makeParsedDataFileName(name))
+		err = os.MkdirAll(filepath.Dir(filePath), 0755)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		unification = &property.Unification{}
-		err = json.Unmarshal(d, unification)
+		err = ioutil.WriteFile(filePath, b, 0644)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
-
-	return &ucd.UCD{
-		UnicodeData:           ud,
-		NameAliases:           nameAliases,
-		DerivedCoreProperties: derivedCoreProps,
-		PropertyAliases:       propAliases,
-		PropertyValueAliases:  propValAliases,
-		PropList:              propList,
-		Unification:           unification,
-	}, nil
+	return nil
 }
 
-func makeParsedDataFilePath(appDirPath string, srcDataFileName string) string {
-	return filepath.Join(appDirPath, "db", makeParsedDataFileName(srcDataFileName))
+// OpenDataFile opens a data file of the UCD saved in the database as it is.
+func OpenDataFile(appDirPath string, dataFileName string) (*os.File, error) {
+	return os.Open(filepath.Join(appDirPath, "db", dataFileName))
 }
 
 func makeParsedDataFileName(srcDataFileName string) string {
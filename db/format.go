@@ -0,0 +1,292 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// The database is a single file laid out as follows. All integers are little-endian.
+//
+//	header     magic "UCDXDB\x00\x00", format version (uint32), and Unicode version (uint16 length + bytes)
+//	directory  number of sections (uint32), and the name (uint16 length + bytes), offset (uint64), and length
+//	           (uint64) of each section. The offsets are relative to the end of the directory.
+//	sections   the parsed data of each data file encoded with encoding/gob
+//
+// Bump dbFormatVersion whenever the layout or the types of the sections change so that OpenDB rejects databases
+// older ucdx made.
+const dbFormatVersion = 1
+
+const dbFileName = "ucdx.db"
+
+var dbMagic = []byte("UCDXDB\x00\x00")
+
+// sectionUnification is the name of the section holding the property.Unification. The other sections are named
+// after their data files.
+const sectionUnification = "unification"
+
+// dbSectionNames are the sections of the database in the order they are written.
+var dbSectionNames = []string{
+	ucd.TxtUnicodeData,
+	ucd.TxtNameAliases,
+	ucd.TxtDerivedCoreProperties,
+	ucd.TxtPropertyAliases,
+	ucd.TxtPropertyValueAliases,
+	ucd.TxtPropList,
+	ucd.TxtScripts,
+	ucd.TxtScriptExtensions,
+	ucd.TxtBlocks,
+	ucd.TxtDerivedNormalizationProps,
+	ucd.TxtLineBreak,
+	ucd.TxtEastAsianWidth,
+	ucd.TxtBidiBrackets,
+	ucd.TxtBidiMirroring,
+	ucd.TxtCaseFolding,
+	ucd.TxtSpecialCasing,
+	ucd.TxtGraphemeBreakProperty,
+	ucd.TxtWordBreakProperty,
+	ucd.TxtSentenceBreakProperty,
+	ucd.TxtEmojiData,
+	sectionUnification,
+}
+
+// newSectionData returns a value a section is decoded into.
+func newSectionData(name string) (interface{}, error) {
+	switch name {
+	case ucd.TxtUnicodeData:
+		return property.NewUnicodeData(), nil
+	case ucd.TxtNameAliases:
+		return &property.NameAliases{}, nil
+	case ucd.TxtDerivedCoreProperties:
+		return &property.DerivedCoreProperties{}, nil
+	case ucd.TxtPropertyAliases:
+		return &property.PropertyAliases{}, nil
+	case ucd.TxtPropertyValueAliases:
+		return &property.PropertyValueAliases{}, nil
+	case ucd.TxtPropList:
+		return &property.PropList{}, nil
+	case ucd.TxtScripts:
+		return &property.Scripts{}, nil
+	case ucd.TxtScriptExtensions:
+		return &property.ScriptExtensions{}, nil
+	case ucd.TxtBlocks:
+		return &property.Blocks{}, nil
+	case ucd.TxtDerivedNormalizationProps:
+		return &property.DerivedNormalizationProps{}, nil
+	case ucd.TxtLineBreak:
+		return &property.LineBreak{}, nil
+	case ucd.TxtEastAsianWidth:
+		return &property.EastAsianWidth{}, nil
+	case ucd.TxtBidiBrackets:
+		return &property.BidiBrackets{}, nil
+	case ucd.TxtBidiMirroring:
+		return &property.BidiMirroring{}, nil
+	case ucd.TxtCaseFolding:
+		return &property.CaseFolding{}, nil
+	case ucd.TxtSpecialCasing:
+		return &property.SpecialCasing{}, nil
+	case ucd.TxtGraphemeBreakProperty:
+		return &property.GraphemeBreakProperty{}, nil
+	case ucd.TxtWordBreakProperty:
+		return &property.WordBreakProperty{}, nil
+	case ucd.TxtSentenceBreakProperty:
+		return &property.SentenceBreakProperty{}, nil
+	case ucd.TxtEmojiData:
+		return &property.EmojiData{}, nil
+	case sectionUnification:
+		return &property.Unification{}, nil
+	}
+	return nil, fmt.Errorf("unknown section: %v", name)
+}
+
+// writeDBFile writes the sections to a database file. `sections` must have all of dbSectionNames.
+func writeDBFile(filePath string, sections map[string]interface{}) error {
+	var body bytes.Buffer
+	var dir bytes.Buffer
+	writeUint32(&dir, uint32(len(dbSectionNames)))
+	for _, name := range dbSectionNames {
+		data, ok := sections[name]
+		if !ok {
+			return fmt.Errorf("section %v is missing", name)
+		}
+		offset := body.Len()
+		err := gob.NewEncoder(&body).Encode(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode section %v: %v", name, err)
+		}
+		writeString(&dir, name)
+		writeUint64(&dir, uint64(offset))
+		writeUint64(&dir, uint64(body.Len()-offset))
+	}
+
+	var b bytes.Buffer
+	b.Write(dbMagic)
+	writeUint32(&b, dbFormatVersion)
+	writeString(&b, ucd.UnicodeVersion)
+	b.Write(dir.Bytes())
+	b.Write(body.Bytes())
+	return ioutil.WriteFile(filePath, b.Bytes(), 0644)
+}
+
+// dbFile is a database file read into memory.
+type dbFile struct {
+	unicodeVersion string
+	sections       map[string][]byte
+}
+
+// errStaleDB is returned when a database has a format other than the one ucdx reads.
+type errStaleDB struct {
+	reason string
+}
+
+func (e *errStaleDB) Error() string {
+	return fmt.Sprintf("the database is stale: %v; run `ucdx setup` to rebuild it", e.reason)
+}
+
+func readDBFile(filePath string) (*dbFile, error) {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &errStaleDB{
+				reason: fmt.Sprintf("%v doesn't exist", filePath),
+			}
+		}
+		return nil, err
+	}
+
+	r := &dbReader{
+		b: b,
+	}
+	if !bytes.Equal(r.bytes(len(dbMagic)), dbMagic) {
+		return nil, fmt.Errorf("%v is not a database of ucdx", filePath)
+	}
+	if v := r.uint32(); r.err == nil && v != dbFormatVersion {
+		return nil, &errStaleDB{
+			reason: fmt.Sprintf("its format version is %v but ucdx reads version %v", v, dbFormatVersion),
+		}
+	}
+	f := &dbFile{
+		unicodeVersion: r.string(),
+		sections:       map[string][]byte{},
+	}
+	if r.err == nil && f.unicodeVersion != ucd.UnicodeVersion {
+		return nil, &errStaleDB{
+			reason: fmt.Sprintf("it contains Unicode %v but ucdx supports Unicode %v", f.unicodeVersion, ucd.UnicodeVersion),
+		}
+	}
+
+	type dirEntry struct {
+		name   string
+		offset uint64
+		length uint64
+	}
+	n := r.uint32()
+	var dir []*dirEntry
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		dir = append(dir, &dirEntry{
+			name:   r.string(),
+			offset: r.uint64(),
+			length: r.uint64(),
+		})
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("%v is broken: %v", filePath, r.err)
+	}
+	body := r.b[r.pos:]
+	for _, e := range dir {
+		if e.offset > uint64(len(body)) || e.length > uint64(len(body))-e.offset {
+			return nil, fmt.Errorf("%v is broken: section %v is out of the file", filePath, e.name)
+		}
+		f.sections[e.name] = body[e.offset : e.offset+e.length]
+	}
+	return f, nil
+}
+
+// decode decodes a section.
+func (f *dbFile) decode(name string) (interface{}, error) {
+	b, ok := f.sections[name]
+	if !ok {
+		return nil, &errStaleDB{
+			reason: fmt.Sprintf("section %v is missing", name),
+		}
+	}
+	data, err := newSectionData(name)
+	if err != nil {
+		return nil, err
+	}
+	err = gob.NewDecoder(bytes.NewReader(b)).Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode section %v: %v", name, err)
+	}
+	return data, nil
+}
+
+func writeUint32(b *bytes.Buffer, v uint32) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	b.Write(buf[:])
+}
+
+func writeUint64(b *bytes.Buffer, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	b.Write(buf[:])
+}
+
+func writeString(b *bytes.Buffer, s string) {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], uint16(len(s)))
+	b.Write(buf[:])
+	b.WriteString(s)
+}
+
+// dbReader reads the header and the directory of a database. Once an error occurs, the subsequent reads return zero
+// values and `err` holds the error.
+type dbReader struct {
+	b   []byte
+	pos int
+	err error
+}
+
+func (r *dbReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.b)-r.pos {
+		r.err = fmt.Errorf("unexpected end of file at %v", r.pos)
+		return nil
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *dbReader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *dbReader) uint64() uint64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (r *dbReader) string() string {
+	b := r.bytes(2)
+	if b == nil {
+		return ""
+	}
+	return string(r.bytes(int(binary.LittleEndian.Uint16(b))))
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+func makeTestSections(t *testing.T) map[string]interface{} {
+	sections := map[string]interface{}{}
+	for _, name := range dbSectionNames {
+		data, err := newSectionData(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sections[name] = data
+	}
+	sections[ucd.TxtScripts] = &property.Scripts{
+		Entries: map[property.PropertyValueSymbol][]*property.CodePointRange{
+			"latin": {
+				property.NewCodePointRange(0x41, 0x5A),
+				property.NewCodePointRange(0x61, 0x7A),
+			},
+		},
+	}
+	return sections
+}
+
+func TestDBFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), dbFileName)
+	err := writeDBFile(filePath, makeTestSections(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := readDBFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.unicodeVersion != ucd.UnicodeVersion {
+		t.Fatalf("unexpected Unicode version: want: %v, got: %v", ucd.UnicodeVersion, f.unicodeVersion)
+	}
+	data, err := f.decode(ucd.TxtScripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cps := data.(*property.Scripts).Entries["latin"]
+	if len(cps) != 2 || *cps[1] != *property.NewCodePointRange(0x61, 0x7A) {
+		t.Fatalf("unexpected ranges: %v", cps)
+	}
+}
+
+func TestDBFile_Stale(t *testing.T) {
+	dirPath := t.TempDir()
+	filePath := filepath.Join(dirPath, dbFileName)
+
+	_, err := readDBFile(filePath)
+	var stale *errStaleDB
+	if !errors.As(err, &stale) {
+		t.Fatalf("a missing database must be stale: %v", err)
+	}
+
+	err = writeDBFile(filePath, makeTestSections(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary.LittleEndian.PutUint32(b[len(dbMagic):], dbFormatVersion+1)
+	err = ioutil.WriteFile(filePath, b, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = readDBFile(filePath)
+	if !errors.As(err, &stale) {
+		t.Fatalf("a database of another format version must be stale: %v", err)
+	}
+
+	err = ioutil.WriteFile(filePath, []byte("{}"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readDBFile(filePath); err == nil {
+		t.Fatalf("an error must occur")
+	}
+}
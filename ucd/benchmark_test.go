@@ -0,0 +1,51 @@
+package ucd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nihei9/ucdx/db"
+	"github.com/nihei9/ucdx/ucd"
+)
+
+// openBenchmarkDB opens the database the setup command makes. The benchmarks are skipped when it doesn't exist.
+func openBenchmarkDB(b *testing.B) *ucd.UCD {
+	homeDirPath, err := os.UserHomeDir()
+	if err != nil {
+		b.Skip(err)
+	}
+	u, err := db.OpenDB(filepath.Join(homeDirPath, ".ucdx"))
+	if err != nil {
+		b.Skipf("the database is unavailable; run `ucdx setup` first: %v", err)
+	}
+	return u
+}
+
+// benchmarkCodePoints are code points of various scripts and blocks, including an ideograph and an unassigned one.
+var benchmarkCodePoints = []rune{
+	'a', 'Z', '0', ' ', 0x00E9, 0x03B1, 0x0416, 0x05D0, 0x0627, 0x0915, 0x0E01, 0x3042, 0x4E00, 0xAC00, 0xFF21,
+	0x1F600, 0x20000, 0xE0001, 0x50000,
+}
+
+func BenchmarkAnalizeCodePoint(b *testing.B) {
+	u := openBenchmarkDB(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u.AnalizeCodePoint(benchmarkCodePoints[i%len(benchmarkCodePoints)])
+	}
+}
+
+func BenchmarkProperty(b *testing.B) {
+	u := openBenchmarkDB(b)
+	for _, name := range []string{"na", "gc", "sc", "scx", "blk", "lb", "Alphabetic", "White_Space"} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := u.Property(benchmarkCodePoints[i%len(benchmarkCodePoints)], name)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
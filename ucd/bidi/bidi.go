@@ -0,0 +1,305 @@
+// Package bidi implements the Unicode Bidirectional Algorithm defined in [UAX9].
+//
+// The package resolves the embedding levels of a paragraph, reorders its characters into visual order, and finds
+// mirrored glyphs of the characters in right-to-left runs. The whole paragraph is treated as a single line, so
+// rule L1 applies to the end of the paragraph.
+package bidi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Class is a value of the Bidi_Class property. See section 3.2 Bidirectional Character Types in [UAX9].
+type Class int
+
+// The zero value is L because code points not listed in UnicodeData.txt default to L.
+const (
+	L Class = iota
+	R
+	AL
+	EN
+	ES
+	ET
+	AN
+	CS
+	NSM
+	BN
+	B
+	S
+	WS
+	ON
+	LRE
+	LRO
+	RLE
+	RLO
+	PDF
+	LRI
+	RLI
+	FSI
+	PDI
+)
+
+var classNames = map[Class]string{
+	L:   "L",
+	R:   "R",
+	AL:  "AL",
+	EN:  "EN",
+	ES:  "ES",
+	ET:  "ET",
+	AN:  "AN",
+	CS:  "CS",
+	NSM: "NSM",
+	BN:  "BN",
+	B:   "B",
+	S:   "S",
+	WS:  "WS",
+	ON:  "ON",
+	LRE: "LRE",
+	LRO: "LRO",
+	RLE: "RLE",
+	RLO: "RLO",
+	PDF: "PDF",
+	LRI: "LRI",
+	RLI: "RLI",
+	FSI: "FSI",
+	PDI: "PDI",
+}
+
+func (c Class) String() string {
+	if name, ok := classNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Class(%d)", int(c))
+}
+
+func (c Class) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// ParseClass returns a class corresponding to a short name of a Bidi_Class value such as `NSM`. The name is
+// case-insensitive, so the normalized symbols of the values are also accepted.
+func ParseClass(name string) (Class, error) {
+	for c, n := range classNames {
+		if strings.EqualFold(name, n) {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown Bidi_Class: %v", name)
+}
+
+// Direction is a paragraph direction. Auto determines the direction of a paragraph from its first strong character
+// following rules P2 and P3.
+type Direction int
+
+const (
+	Auto Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+var directionNames = map[Direction]string{
+	Auto:        "auto",
+	LeftToRight: "ltr",
+	RightToLeft: "rtl",
+}
+
+func (d Direction) String() string {
+	if name, ok := directionNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+func (d Direction) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// ParseDirection returns a direction corresponding to a name such as `rtl`. The name is case-insensitive.
+func ParseDirection(name string) (Direction, error) {
+	for d, n := range directionNames {
+		if strings.EqualFold(name, n) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown paragraph direction: %v", name)
+}
+
+// Paragraph is a paragraph whose embedding levels are resolved.
+type Paragraph struct {
+	// Level is the paragraph embedding level.
+	Level int `json:"level"`
+
+	// Levels holds the resolved embedding levels of the characters. Characters removed by rule X9 have -1.
+	Levels []int `json:"levels"`
+}
+
+// VisualOrder returns the indexes of the characters in visual order following rule L2. Characters removed by rule
+// X9 are not included.
+func (p *Paragraph) VisualOrder() []int {
+	var order []int
+	highest := 0
+	lowestOdd := maxDepth + 2
+	for i, l := range p.Levels {
+		if l < 0 {
+			continue
+		}
+		order = append(order, i)
+		if l > highest {
+			highest = l
+		}
+		if l%2 == 1 && l < lowestOdd {
+			lowestOdd = l
+		}
+	}
+
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(order); {
+			if p.Levels[order[i]] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(order) && p.Levels[order[j]] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
+
+// Resolver resolves paragraphs of text using the Bidi_Class, Bidi_Paired_Bracket, Bidi_Paired_Bracket_Type, and
+// Bidi_Mirroring_Glyph properties.
+type Resolver struct {
+	classes      rangeTable
+	brackets     *property.BidiBrackets
+	mirroring    *property.BidiMirroring
+	canonicalEqs map[rune]rune
+}
+
+func NewResolver(ud *property.UnicodeData, brackets *property.BidiBrackets, mirroring *property.BidiMirroring) *Resolver {
+	values := map[property.PropertyValueSymbol]int{}
+	for c, name := range classNames {
+		values[property.NewSymbolPropertyValue(strings.ToLower(name))] = int(c)
+	}
+
+	// Rule BD16 matches brackets considering canonical equivalence, such as U+2329 and U+3008. Such brackets have
+	// singleton decompositions.
+	canonicalEqs := map[rune]rune{}
+	for c := range brackets.PairedBracket {
+		d, ok := ud.Decomposition[c]
+		if !ok || d.Type != "canonical" || len(d.Mapping) != 1 {
+			continue
+		}
+		canonicalEqs[c] = d.Mapping[0]
+	}
+
+	return &Resolver{
+		classes:      newRangeTable(ud.BidiClass, values),
+		brackets:     brackets,
+		mirroring:    mirroring,
+		canonicalEqs: canonicalEqs,
+	}
+}
+
+// Class returns the Bidi_Class value of a code point.
+func (r *Resolver) Class(c rune) Class {
+	return Class(r.classes.lookup(c))
+}
+
+// Resolve resolves the embedding levels of a paragraph.
+func (r *Resolver) Resolve(rs []rune, dir Direction) *Paragraph {
+	classes := make([]Class, len(rs))
+	brackets := make([]bracket, len(rs))
+	for i, c := range rs {
+		classes[i] = r.Class(c)
+		switch r.brackets.PairedBracketType[c] {
+		case "o":
+			brackets[i] = bracket{
+				kind: bracketOpen,
+				id:   r.canonical(c),
+			}
+		case "c":
+			brackets[i] = bracket{
+				kind: bracketClose,
+				id:   r.canonical(r.brackets.PairedBracket[c]),
+			}
+		}
+	}
+	return resolveParagraph(classes, brackets, dir)
+}
+
+// canonical returns a representative of the brackets canonically equivalent to a bracket.
+func (r *Resolver) canonical(c rune) rune {
+	if eq, ok := r.canonicalEqs[c]; ok {
+		return eq
+	}
+	return c
+}
+
+// Mirror returns the mirrored glyph of a character at an embedding level following rule L4. The second result is
+// false when the character isn't mirrored at the level or has no mirrored glyph.
+func (r *Resolver) Mirror(c rune, level int) (rune, bool) {
+	if level < 0 || level%2 == 0 {
+		return c, false
+	}
+	if m, ok := r.mirroring.MirroringGlyph[c]; ok {
+		return m, true
+	}
+	return c, false
+}
+
+// ResolveClasses resolves the embedding levels of a paragraph consisting of characters having the classes. Because
+// the characters have no code points, rule N0 never pairs brackets.
+func ResolveClasses(classes []Class, dir Direction) *Paragraph {
+	return resolveParagraph(classes, make([]bracket, len(classes)), dir)
+}
+
+// rangeTable maps code points to values. The table is sorted by code points so that it can be searched in
+// logarithmic time.
+type rangeTable []*valueRange
+
+type valueRange struct {
+	from  rune
+	to    rune
+	value int
+}
+
+func newRangeTable(entries map[property.PropertyValueSymbol][]*property.CodePointRange, values map[property.PropertyValueSymbol]int) rangeTable {
+	var t rangeTable
+	for v, cps := range entries {
+		value, ok := values[v]
+		if !ok {
+			continue
+		}
+		for _, cp := range cps {
+			from, to := cp.Range()
+			t = append(t, &valueRange{
+				from:  from,
+				to:    to,
+				value: value,
+			})
+		}
+	}
+	sort.Slice(t, func(i, j int) bool {
+		return t[i].from < t[j].from
+	})
+	return t
+}
+
+func (t rangeTable) lookup(c rune) int {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].to >= c
+	})
+	if i < len(t) && t[i].from <= c {
+		return t[i].value
+	}
+	return 0
+}
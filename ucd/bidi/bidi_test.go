@@ -0,0 +1,224 @@
+package bidi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+func TestResolveClasses(t *testing.T) {
+	tests := []struct {
+		classes string
+		dir     Direction
+		level   int
+		levels  []int
+		order   []int
+	}{
+		{
+			classes: "L R",
+			dir:     LeftToRight,
+			level:   0,
+			levels:  []int{0, 1},
+			order:   []int{0, 1},
+		},
+		{
+			classes: "R L",
+			dir:     Auto,
+			level:   1,
+			levels:  []int{1, 2},
+			order:   []int{1, 0},
+		},
+		// W2 and W3
+		{
+			classes: "AL EN",
+			dir:     Auto,
+			level:   1,
+			levels:  []int{1, 2},
+			order:   []int{1, 0},
+		},
+		// W7
+		{
+			classes: "L EN",
+			dir:     RightToLeft,
+			level:   1,
+			levels:  []int{2, 2},
+			order:   []int{0, 1},
+		},
+		// W4
+		{
+			classes: "EN ES EN",
+			dir:     RightToLeft,
+			level:   1,
+			levels:  []int{2, 2, 2},
+			order:   []int{0, 1, 2},
+		},
+		// W5
+		{
+			classes: "ET EN",
+			dir:     LeftToRight,
+			level:   0,
+			levels:  []int{0, 0},
+			order:   []int{0, 1},
+		},
+		// X9 removes the embedding characters.
+		{
+			classes: "RLE L PDF",
+			dir:     LeftToRight,
+			level:   0,
+			levels:  []int{-1, 2, -1},
+			order:   []int{1},
+		},
+		// N2 and L1
+		{
+			classes: "L WS R WS",
+			dir:     LeftToRight,
+			level:   0,
+			levels:  []int{0, 0, 1, 0},
+			order:   []int{0, 1, 2, 3},
+		},
+		// An isolate is resolved separately from the text surrounding it.
+		{
+			classes: "RLI L PDI R",
+			dir:     LeftToRight,
+			level:   0,
+			levels:  []int{0, 2, 0, 1},
+			order:   []int{0, 1, 2, 3},
+		},
+		// P2 skips characters in isolates.
+		{
+			classes: "LRI L PDI R",
+			dir:     Auto,
+			level:   1,
+			levels:  []int{1, 2, 1, 1},
+			order:   []int{3, 2, 1, 0},
+		},
+		// An override changes the directions of characters.
+		{
+			classes: "RLO L L PDF",
+			dir:     LeftToRight,
+			level:   0,
+			levels:  []int{-1, 1, 1, -1},
+			order:   []int{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v (%v)", tt.classes, tt.dir), func(t *testing.T) {
+			var classes []Class
+			for _, name := range strings.Fields(tt.classes) {
+				c, err := ParseClass(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				classes = append(classes, c)
+			}
+			p := ResolveClasses(classes, tt.dir)
+			if p.Level != tt.level {
+				t.Fatalf("unexpected paragraph level: want: %v, got: %v", tt.level, p.Level)
+			}
+			if fmt.Sprint(p.Levels) != fmt.Sprint(tt.levels) {
+				t.Fatalf("unexpected levels: want: %v, got: %v", tt.levels, p.Levels)
+			}
+			if order := p.VisualOrder(); fmt.Sprint(order) != fmt.Sprint(tt.order) {
+				t.Fatalf("unexpected visual order: want: %v, got: %v", tt.order, order)
+			}
+		})
+	}
+}
+
+const testUnicodeData = `
+0028;LEFT PARENTHESIS;Ps;0;ON;;;;;Y;OPENING PARENTHESIS;;;;
+0029;RIGHT PARENTHESIS;Pe;0;ON;;;;;Y;CLOSING PARENTHESIS;;;;
+0061;LATIN SMALL LETTER A;Ll;0;L;;;;;N;;;0041;;0041
+05D0;HEBREW LETTER ALEF;Lo;0;R;;;;;N;;;;;
+2329;LEFT-POINTING ANGLE BRACKET;Ps;0;ON;3008;;;;Y;BRA;;;;
+232A;RIGHT-POINTING ANGLE BRACKET;Pe;0;ON;3009;;;;Y;KET;;;;
+3008;LEFT ANGLE BRACKET;Ps;0;ON;;;;;Y;OPENING ANGLE BRACKET;;;;
+3009;RIGHT ANGLE BRACKET;Pe;0;ON;;;;;Y;CLOSING ANGLE BRACKET;;;;
+`
+
+const testBidiBrackets = `
+0028; 0029; o # LEFT PARENTHESIS
+0029; 0028; c # RIGHT PARENTHESIS
+2329; 232A; o # LEFT-POINTING ANGLE BRACKET
+232A; 2329; c # RIGHT-POINTING ANGLE BRACKET
+3008; 3009; o # LEFT ANGLE BRACKET
+3009; 3008; c # RIGHT ANGLE BRACKET
+`
+
+const testBidiMirroring = `
+0028; 0029 # LEFT PARENTHESIS
+0029; 0028 # RIGHT PARENTHESIS
+2329; 232A # LEFT-POINTING ANGLE BRACKET
+232A; 2329 # RIGHT-POINTING ANGLE BRACKET
+3008; 3009 # LEFT ANGLE BRACKET
+3009; 3008 # RIGHT ANGLE BRACKET
+`
+
+func TestResolver(t *testing.T) {
+	ud, err := parser.ParseUnicodeData(strings.NewReader(testUnicodeData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	brackets, err := parser.ParseBidiBrackets(strings.NewReader(testBidiBrackets))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirroring, err := parser.ParseBidiMirroring(strings.NewReader(testBidiMirroring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewResolver(ud, brackets, mirroring)
+
+	tests := []struct {
+		src    string
+		dir    Direction
+		levels []int
+		order  []int
+	}{
+		// N0 resolves the brackets to R because the context before the opening bracket is R. Without N0, the closing
+		// bracket would be L.
+		{
+			src:    "\u05D0(\u05D0)a",
+			dir:    LeftToRight,
+			levels: []int{1, 1, 1, 1, 0},
+			order:  []int{3, 2, 1, 0, 4},
+		},
+		// BD16 pairs canonically equivalent brackets.
+		{
+			src:    "\u05D0\u2329\u05D0\u3009a",
+			dir:    LeftToRight,
+			levels: []int{1, 1, 1, 1, 0},
+			order:  []int{3, 2, 1, 0, 4},
+		},
+		// The brackets take the embedding direction because the context before them isn't opposite to it.
+		{
+			src:    "\u05D0(a)",
+			dir:    RightToLeft,
+			levels: []int{1, 1, 2, 1},
+			order:  []int{3, 2, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+q", tt.src), func(t *testing.T) {
+			p := r.Resolve([]rune(tt.src), tt.dir)
+			if fmt.Sprint(p.Levels) != fmt.Sprint(tt.levels) {
+				t.Fatalf("unexpected levels: want: %v, got: %v", tt.levels, p.Levels)
+			}
+			if order := p.VisualOrder(); fmt.Sprint(order) != fmt.Sprint(tt.order) {
+				t.Fatalf("unexpected visual order: want: %v, got: %v", tt.order, order)
+			}
+		})
+	}
+
+	if m, ok := r.Mirror('(', 1); !ok || m != ')' {
+		t.Fatalf("( must be mirrored at an odd level: %q, %v", m, ok)
+	}
+	if _, ok := r.Mirror('(', 0); ok {
+		t.Fatalf("( must not be mirrored at an even level")
+	}
+	if _, ok := r.Mirror('a', 1); ok {
+		t.Fatalf("a has no mirrored glyph")
+	}
+}
@@ -0,0 +1,637 @@
+package bidi
+
+// maxDepth is the maximum explicit embedding level. See section 3.3.2 Explicit Levels and Directions in [UAX9].
+const maxDepth = 125
+
+// maxBracketPairs is the size of the stack rule BD16 uses to find bracket pairs.
+const maxBracketPairs = 63
+
+const (
+	bracketNone = iota
+	bracketOpen
+	bracketClose
+)
+
+// bracket is the Bidi_Paired_Bracket_Type of a character and an identifier of its bracket pair. An opening bracket
+// and a closing bracket form a pair when their identifiers are equal.
+type bracket struct {
+	kind int
+	id   rune
+}
+
+// paragraph holds the state of resolving a paragraph.
+type paragraph struct {
+	// initialClasses are the original classes of the characters, and classes are the ones the rules change.
+	initialClasses []Class
+	classes        []Class
+	brackets       []bracket
+	levels         []int
+	level          int
+
+	// matchingPDI holds the indexes of the PDIs matching the isolate initiators, and matchingInitiator holds the
+	// indexes of the isolate initiators matching the PDIs. An isolate initiator without a matching PDI has the
+	// length of the paragraph, and a PDI without a matching isolate initiator has -1.
+	matchingPDI       []int
+	matchingInitiator []int
+}
+
+func resolveParagraph(classes []Class, brackets []bracket, dir Direction) *Paragraph {
+	p := &paragraph{
+		initialClasses:    classes,
+		classes:           make([]Class, len(classes)),
+		brackets:          brackets,
+		levels:            make([]int, len(classes)),
+		matchingPDI:       make([]int, len(classes)),
+		matchingInitiator: make([]int, len(classes)),
+	}
+	copy(p.classes, classes)
+
+	p.matchIsolates()
+	switch dir {
+	case LeftToRight:
+		p.level = 0
+	case RightToLeft:
+		p.level = 1
+	default:
+		p.level = p.firstStrongLevel(0, len(classes))
+	}
+	p.resolveExplicitLevels()
+	for _, seq := range p.isolatingRunSequences() {
+		seq.resolveWeakTypes()
+		seq.resolvePairedBrackets()
+		seq.resolveNeutralTypes()
+		seq.resolveImplicitLevels()
+	}
+	p.resetWhitespaceLevels()
+
+	for i, c := range p.initialClasses {
+		if isRemovedByX9(c) {
+			p.levels[i] = -1
+		}
+	}
+	return &Paragraph{
+		Level:  p.level,
+		Levels: p.levels,
+	}
+}
+
+// matchIsolates finds the PDIs matching the isolate initiators following rule BD9.
+func (p *paragraph) matchIsolates() {
+	n := len(p.initialClasses)
+	for i := range p.matchingInitiator {
+		p.matchingInitiator[i] = -1
+	}
+	for i, c := range p.initialClasses {
+		if !isIsolateInitiator(c) {
+			continue
+		}
+		p.matchingPDI[i] = n
+		depth := 1
+	L:
+		for j := i + 1; j < n; j++ {
+			switch c := p.initialClasses[j]; {
+			case isIsolateInitiator(c):
+				depth++
+			case c == PDI:
+				depth--
+				if depth == 0 {
+					p.matchingPDI[i] = j
+					p.matchingInitiator[j] = i
+					break L
+				}
+			case c == B:
+				break L
+			}
+		}
+	}
+}
+
+// firstStrongLevel determines an embedding level from the first strong character in [from, to) following rules P2
+// and P3. Characters between an isolate initiator and its matching PDI are skipped. The level is 0 when there is no
+// strong character.
+func (p *paragraph) firstStrongLevel(from, to int) int {
+	for i := from; i < to; i++ {
+		switch c := p.initialClasses[i]; {
+		case c == L:
+			return 0
+		case c == R || c == AL:
+			return 1
+		case c == B:
+			return 0
+		case isIsolateInitiator(c):
+			i = p.matchingPDI[i]
+		}
+	}
+	return 0
+}
+
+type directionalStatus struct {
+	level    int
+	override Class
+	isolate  bool
+}
+
+// resolveExplicitLevels determines explicit embedding levels and directions following rules X1 to X8.
+func (p *paragraph) resolveExplicitLevels() {
+	// X1
+	stack := []*directionalStatus{
+		{
+			level:    p.level,
+			override: ON,
+		},
+	}
+	overflowIsolates := 0
+	overflowEmbeddings := 0
+	validIsolates := 0
+
+	for i, c := range p.initialClasses {
+		top := stack[len(stack)-1]
+		switch c {
+		case RLE, LRE, RLO, LRO:
+			// X2 to X5
+			level := nextLevel(top.level, c == RLE || c == RLO)
+			if level <= maxDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				override := ON
+				switch c {
+				case RLO:
+					override = R
+				case LRO:
+					override = L
+				}
+				stack = append(stack, &directionalStatus{
+					level:    level,
+					override: override,
+				})
+			} else if overflowIsolates == 0 {
+				overflowEmbeddings++
+			}
+			// The level doesn't matter because rule X9 removes these characters.
+			p.levels[i] = top.level
+		case RLI, LRI, FSI:
+			// X5a to X5c
+			p.levels[i] = top.level
+			if top.override != ON {
+				p.classes[i] = top.override
+			}
+			rtl := c == RLI
+			if c == FSI {
+				rtl = p.firstStrongLevel(i+1, p.matchingPDI[i]) == 1
+			}
+			level := nextLevel(top.level, rtl)
+			if level <= maxDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				validIsolates++
+				stack = append(stack, &directionalStatus{
+					level:    level,
+					override: ON,
+					isolate:  true,
+				})
+			} else {
+				overflowIsolates++
+			}
+		case PDI:
+			// X6a
+			if overflowIsolates > 0 {
+				overflowIsolates--
+			} else if validIsolates > 0 {
+				overflowEmbeddings = 0
+				for !stack[len(stack)-1].isolate {
+					stack = stack[:len(stack)-1]
+				}
+				stack = stack[:len(stack)-1]
+				validIsolates--
+			}
+			top = stack[len(stack)-1]
+			p.levels[i] = top.level
+			if top.override != ON {
+				p.classes[i] = top.override
+			}
+		case PDF:
+			// X7
+			if overflowIsolates > 0 {
+			} else if overflowEmbeddings > 0 {
+				overflowEmbeddings--
+			} else if !top.isolate && len(stack) >= 2 {
+				stack = stack[:len(stack)-1]
+			}
+			p.levels[i] = top.level
+		case B:
+			// X8
+			stack = stack[:1]
+			overflowIsolates = 0
+			overflowEmbeddings = 0
+			validIsolates = 0
+			p.levels[i] = p.level
+		case BN:
+			p.levels[i] = top.level
+		default:
+			// X6
+			p.levels[i] = top.level
+			if top.override != ON {
+				p.classes[i] = top.override
+			}
+		}
+	}
+}
+
+// nextLevel returns the least odd level greater than a level when rtl is true, otherwise the least even level.
+func nextLevel(level int, rtl bool) int {
+	if rtl {
+		return (level + 1) | 1
+	}
+	return (level + 2) &^ 1
+}
+
+// isolatingRunSequence is an isolating run sequence defined in rule BD13.
+type isolatingRunSequence struct {
+	p       *paragraph
+	indexes []int
+	classes []Class
+	level   int
+	sos     Class
+	eos     Class
+}
+
+// isolatingRunSequences splits the paragraph into isolating run sequences following rules X9 and X10.
+func (p *paragraph) isolatingRunSequences() []*isolatingRunSequence {
+	// BD7: level runs consist of the characters rule X9 doesn't remove.
+	var runs [][]int
+	runOf := make([]int, len(p.initialClasses))
+	for i, c := range p.initialClasses {
+		if isRemovedByX9(c) {
+			continue
+		}
+		if len(runs) == 0 || p.levels[runs[len(runs)-1][0]] != p.levels[i] {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], i)
+		runOf[i] = len(runs) - 1
+	}
+
+	var seqs []*isolatingRunSequence
+	for _, run := range runs {
+		if first := run[0]; p.initialClasses[first] == PDI && p.matchingInitiator[first] >= 0 {
+			continue
+		}
+		var indexes []int
+		for {
+			indexes = append(indexes, run...)
+			last := indexes[len(indexes)-1]
+			if !isIsolateInitiator(p.initialClasses[last]) || p.matchingPDI[last] >= len(p.initialClasses) {
+				break
+			}
+			run = runs[runOf[p.matchingPDI[last]]]
+		}
+		seqs = append(seqs, p.newIsolatingRunSequence(indexes))
+	}
+	return seqs
+}
+
+func (p *paragraph) newIsolatingRunSequence(indexes []int) *isolatingRunSequence {
+	seq := &isolatingRunSequence{
+		p:       p,
+		indexes: indexes,
+		classes: make([]Class, len(indexes)),
+		level:   p.levels[indexes[0]],
+	}
+	for i, idx := range indexes {
+		seq.classes[i] = p.classes[idx]
+	}
+
+	prevLevel := p.level
+	for i := indexes[0] - 1; i >= 0; i-- {
+		if !isRemovedByX9(p.initialClasses[i]) {
+			prevLevel = p.levels[i]
+			break
+		}
+	}
+	succLevel := p.level
+	if last := indexes[len(indexes)-1]; !isIsolateInitiator(p.initialClasses[last]) {
+		for i := last + 1; i < len(p.initialClasses); i++ {
+			if !isRemovedByX9(p.initialClasses[i]) {
+				succLevel = p.levels[i]
+				break
+			}
+		}
+	}
+	seq.sos = directionOfLevel(maxInt(prevLevel, seq.level))
+	seq.eos = directionOfLevel(maxInt(succLevel, seq.level))
+	return seq
+}
+
+// resolveWeakTypes resolves weak types following rules W1 to W7.
+func (s *isolatingRunSequence) resolveWeakTypes() {
+	cs := s.classes
+
+	// W1
+	for i, c := range cs {
+		if c != NSM {
+			continue
+		}
+		switch {
+		case i == 0:
+			cs[i] = s.sos
+		case isIsolateInitiator(cs[i-1]) || cs[i-1] == PDI:
+			cs[i] = ON
+		default:
+			cs[i] = cs[i-1]
+		}
+	}
+
+	// W2
+	for i, c := range cs {
+		if c == EN && s.precedingStrong(i, true) == AL {
+			cs[i] = AN
+		}
+	}
+
+	// W3
+	for i, c := range cs {
+		if c == AL {
+			cs[i] = R
+		}
+	}
+
+	// W4
+	for i := 1; i < len(cs)-1; i++ {
+		prev, next := cs[i-1], cs[i+1]
+		switch {
+		case cs[i] == ES && prev == EN && next == EN:
+			cs[i] = EN
+		case cs[i] == CS && prev == EN && next == EN:
+			cs[i] = EN
+		case cs[i] == CS && prev == AN && next == AN:
+			cs[i] = AN
+		}
+	}
+
+	// W5
+	for i := 0; i < len(cs); {
+		if cs[i] != ET {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cs) && cs[j] == ET {
+			j++
+		}
+		if (i > 0 && cs[i-1] == EN) || (j < len(cs) && cs[j] == EN) {
+			for k := i; k < j; k++ {
+				cs[k] = EN
+			}
+		}
+		i = j
+	}
+
+	// W6
+	for i, c := range cs {
+		if c == ES || c == ET || c == CS {
+			cs[i] = ON
+		}
+	}
+
+	// W7
+	for i, c := range cs {
+		if c == EN && s.precedingStrong(i, false) == L {
+			cs[i] = L
+		}
+	}
+}
+
+// precedingStrong returns the first strong type preceding the position i, or sos when there is none. AL is a strong
+// type only when al is true.
+func (s *isolatingRunSequence) precedingStrong(i int, al bool) Class {
+	for j := i - 1; j >= 0; j-- {
+		switch c := s.classes[j]; {
+		case c == L || c == R:
+			return c
+		case c == AL && al:
+			return c
+		}
+	}
+	return s.sos
+}
+
+type bracketPair struct {
+	open  int
+	close int
+}
+
+// resolvePairedBrackets resolves paired brackets following rule N0.
+func (s *isolatingRunSequence) resolvePairedBrackets() {
+	e := directionOfLevel(s.level)
+	for _, pair := range s.bracketPairs() {
+		found := ON
+		for i := pair.open + 1; i < pair.close; i++ {
+			d := strongDirection(s.classes[i])
+			if d == ON {
+				continue
+			}
+			found = d
+			if d == e {
+				break
+			}
+		}
+
+		var d Class
+		switch {
+		case found == ON:
+			// N0 d: leave the brackets unchanged.
+			continue
+		case found == e:
+			// N0 b
+			d = e
+		default:
+			// N0 c: use the direction of the context before the opening bracket when it is opposite to the
+			// embedding direction.
+			d = e
+			ctx := s.sos
+			for i := pair.open - 1; i >= 0; i-- {
+				if c := strongDirection(s.classes[i]); c != ON {
+					ctx = c
+					break
+				}
+			}
+			if ctx == found {
+				d = found
+			}
+		}
+		s.setBracketDirection(pair.open, d)
+		s.setBracketDirection(pair.close, d)
+	}
+}
+
+// setBracketDirection sets the direction of a bracket. Nonspacing marks originally following the bracket take the
+// same direction.
+func (s *isolatingRunSequence) setBracketDirection(i int, d Class) {
+	s.classes[i] = d
+	for j := i + 1; j < len(s.indexes) && s.p.initialClasses[s.indexes[j]] == NSM; j++ {
+		s.classes[j] = d
+	}
+}
+
+// bracketPairs identifies bracket pairs following rule BD16. The pairs are sorted by the positions of their opening
+// brackets.
+func (s *isolatingRunSequence) bracketPairs() []*bracketPair {
+	type opener struct {
+		id  rune
+		pos int
+	}
+	var stack []opener
+	var pairs []*bracketPair
+L:
+	for i, idx := range s.indexes {
+		if s.classes[i] != ON {
+			continue
+		}
+		b := s.p.brackets[idx]
+		switch b.kind {
+		case bracketOpen:
+			if len(stack) >= maxBracketPairs {
+				break L
+			}
+			stack = append(stack, opener{
+				id:  b.id,
+				pos: i,
+			})
+		case bracketClose:
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].id == b.id {
+					pairs = append(pairs, &bracketPair{
+						open:  stack[j].pos,
+						close: i,
+					})
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+	}
+
+	// Sorting by insertion keeps the order of the pairs having the same opening position, which never happens.
+	for i := 1; i < len(pairs); i++ {
+		for j := i; j > 0 && pairs[j-1].open > pairs[j].open; j-- {
+			pairs[j-1], pairs[j] = pairs[j], pairs[j-1]
+		}
+	}
+	return pairs
+}
+
+// resolveNeutralTypes resolves neutral and isolate formatting characters following rules N1 and N2.
+func (s *isolatingRunSequence) resolveNeutralTypes() {
+	cs := s.classes
+	e := directionOfLevel(s.level)
+	for i := 0; i < len(cs); {
+		if !isNeutralOrIsolate(cs[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cs) && isNeutralOrIsolate(cs[j]) {
+			j++
+		}
+		leading := s.sos
+		if i > 0 {
+			leading = strongDirection(cs[i-1])
+		}
+		trailing := s.eos
+		if j < len(cs) {
+			trailing = strongDirection(cs[j])
+		}
+		d := e
+		if leading == trailing {
+			d = leading
+		}
+		for k := i; k < j; k++ {
+			cs[k] = d
+		}
+		i = j
+	}
+}
+
+// resolveImplicitLevels resolves the embedding levels following rules I1 and I2.
+func (s *isolatingRunSequence) resolveImplicitLevels() {
+	for i, idx := range s.indexes {
+		c := s.classes[i]
+		s.p.classes[idx] = c
+		if s.level%2 == 0 {
+			switch c {
+			case R:
+				s.p.levels[idx] = s.level + 1
+			case AN, EN:
+				s.p.levels[idx] = s.level + 2
+			}
+		} else {
+			switch c {
+			case L, EN, AN:
+				s.p.levels[idx] = s.level + 1
+			}
+		}
+	}
+}
+
+// resetWhitespaceLevels resets the levels of separators and whitespace following rule L1. The end of the paragraph
+// is treated as the end of a line.
+func (p *paragraph) resetWhitespaceLevels() {
+	reset := func(end int) {
+		for i := end - 1; i >= 0 && isWhitespaceForL1(p.initialClasses[i]); i-- {
+			p.levels[i] = p.level
+		}
+	}
+	for i, c := range p.initialClasses {
+		if c == S || c == B {
+			p.levels[i] = p.level
+			reset(i)
+		}
+	}
+	reset(len(p.initialClasses))
+}
+
+// strongDirection returns the direction a class has in rules N0 to N2; EN and AN behave as R. It returns ON for the
+// other classes.
+func strongDirection(c Class) Class {
+	switch c {
+	case L:
+		return L
+	case R, AL, EN, AN:
+		return R
+	}
+	return ON
+}
+
+func directionOfLevel(level int) Class {
+	if level%2 == 0 {
+		return L
+	}
+	return R
+}
+
+func isIsolateInitiator(c Class) bool {
+	return c == LRI || c == RLI || c == FSI
+}
+
+func isRemovedByX9(c Class) bool {
+	switch c {
+	case RLE, LRE, RLO, LRO, PDF, BN:
+		return true
+	}
+	return false
+}
+
+func isNeutralOrIsolate(c Class) bool {
+	switch c {
+	case B, S, WS, ON, LRI, RLI, FSI, PDI:
+		return true
+	}
+	return false
+}
+
+func isWhitespaceForL1(c Class) bool {
+	return c == WS || isIsolateInitiator(c) || c == PDI || isRemovedByX9(c)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
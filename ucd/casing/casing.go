@@ -0,0 +1,387 @@
+// Package casing converts the case of text following the default case algorithms defined in section 3.13 Default
+// Case Algorithms in [Unicode].
+//
+// The conversions use the full case mappings, which are the ones SpecialCasing.txt defines together with the
+// simple ones in UnicodeData.txt, and they apply the context-sensitive mappings such as Final_Sigma. The mappings for
+// Lithuanian, Turkish, and Azeri apply only when the language is specified.
+package casing
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/nihei9/ucdx/ucd/segment"
+)
+
+// Language is a language ID that SpecialCasing.txt uses in its conditions. The zero value means no language-specific
+// mappings apply.
+type Language string
+
+const (
+	LanguageNone       Language = ""
+	LanguageLithuanian Language = "lt"
+	LanguageTurkish    Language = "tr"
+	LanguageAzeri      Language = "az"
+)
+
+// ParseLanguage returns a language corresponding to a language ID such as `tr`. An empty string results in
+// LanguageNone.
+func ParseLanguage(id string) (Language, error) {
+	switch l := Language(strings.ToLower(id)); l {
+	case LanguageNone, LanguageLithuanian, LanguageTurkish, LanguageAzeri:
+		return l, nil
+	}
+	return "", fmt.Errorf("unsupported language: %v", id)
+}
+
+func (l Language) turkic() bool {
+	return l == LanguageTurkish || l == LanguageAzeri
+}
+
+// Mapping is a kind of case conversion.
+type Mapping int
+
+const (
+	Upper Mapping = iota
+	Lower
+	Title
+	Fold
+)
+
+var mappingNames = map[Mapping]string{
+	Upper: "upper",
+	Lower: "lower",
+	Title: "title",
+	Fold:  "fold",
+}
+
+func (m Mapping) String() string {
+	if name, ok := mappingNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Mapping(%d)", int(m))
+}
+
+// ParseMapping returns a mapping corresponding to a name such as `upper`. The name is case-insensitive.
+func ParseMapping(name string) (Mapping, error) {
+	for m, n := range mappingNames {
+		if strings.EqualFold(name, n) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown case mapping: %v", name)
+}
+
+const (
+	combiningDotAbove = 0x0307
+	latinCapitalI     = 0x0049
+)
+
+// Mapper converts the case of text.
+type Mapper struct {
+	upper       map[rune]rune
+	lower       map[rune]rune
+	title       map[rune]rune
+	special     map[rune][]*property.SpecialCasingEntry
+	cf          *property.CaseFolding
+	cased       rangeTable
+	ignorable   rangeTable
+	softDotted  rangeTable
+	ccc         rangeTable
+	wordBreaker segment.Breaker
+}
+
+// NewMapper returns a mapper. The word breaker determines the words the title case conversion capitalizes.
+func NewMapper(ud *property.UnicodeData, sc *property.SpecialCasing, cf *property.CaseFolding, dcp *property.DerivedCoreProperties, pl *property.PropList, wordBreaker segment.Breaker) *Mapper {
+	special := map[rune][]*property.SpecialCasingEntry{}
+	for _, e := range sc.Entries {
+		special[e.CP] = append(special[e.CP], e)
+	}
+
+	// Code points not listed in UnicodeData.txt have the Canonical_Combining_Class 0, which is the zero value of the
+	// table.
+	var ccc rangeTable
+	for v, cps := range ud.CanonicalCombiningClass {
+		n, err := strconv.Atoi(v.String())
+		if err != nil || n == 0 {
+			continue
+		}
+		ccc = append(ccc, newRangeTable(cps, n)...)
+	}
+	sortRangeTable(ccc)
+
+	return &Mapper{
+		upper:       ud.SimpleUppercaseMapping,
+		lower:       ud.SimpleLowercaseMapping,
+		title:       ud.SimpleTitlecaseMapping,
+		special:     special,
+		cf:          cf,
+		cased:       newRangeTable(dcp.Entries[property.PropNameCased], 1),
+		ignorable:   newRangeTable(dcp.Entries[property.PropNameCaseIgnorable], 1),
+		softDotted:  newRangeTable(pl.Entries[property.PropNameSoftDotted], 1),
+		ccc:         ccc,
+		wordBreaker: wordBreaker,
+	}
+}
+
+// String converts the case of a string.
+func (m *Mapper) String(s string, mapping Mapping, lang Language) string {
+	return string(m.Runes([]rune(s), mapping, lang))
+}
+
+// Runes converts the case of a sequence of code points.
+func (m *Mapper) Runes(rs []rune, mapping Mapping, lang Language) []rune {
+	switch mapping {
+	case Upper:
+		return m.convert(rs, lang, func(int) Mapping { return Upper })
+	case Lower:
+		return m.convert(rs, lang, func(int) Mapping { return Lower })
+	case Title:
+		return m.toTitle(rs, lang)
+	case Fold:
+		var out []rune
+		for _, c := range rs {
+			out = append(out, m.fold(c, lang)...)
+		}
+		return out
+	}
+	return rs
+}
+
+// toTitle converts text following the definition of toTitlecase(X); the first cased character of each word is
+// mapped to its titlecase, and the other characters are mapped to their lowercase.
+func (m *Mapper) toTitle(rs []rune, lang Language) []rune {
+	mappings := make([]Mapping, len(rs))
+	first := true
+	for i, b := range m.wordBreaker.Boundaries(rs) {
+		if i >= len(rs) {
+			break
+		}
+		if b.Break {
+			first = true
+		}
+		mappings[i] = Lower
+		if first && m.cased.contain(rs[i]) {
+			mappings[i] = Title
+			first = false
+		}
+	}
+	return m.convert(rs, lang, func(i int) Mapping { return mappings[i] })
+}
+
+func (m *Mapper) convert(rs []rune, lang Language, mappingAt func(i int) Mapping) []rune {
+	var out []rune
+	for i := range rs {
+		out = append(out, m.mapAt(rs, i, mappingAt(i), lang)...)
+	}
+	return out
+}
+
+// mapAt maps a character at the position i. A conditional mapping of SpecialCasing.txt takes precedence over an
+// unconditional one, and the simple mappings apply when SpecialCasing.txt has no mapping for the character.
+func (m *Mapper) mapAt(rs []rune, i int, mapping Mapping, lang Language) []rune {
+	c := rs[i]
+	var unconditional *property.SpecialCasingEntry
+	for _, e := range m.special[c] {
+		if len(e.Conditions) == 0 {
+			unconditional = e
+			continue
+		}
+		if m.satisfy(rs, i, e.Conditions, lang) {
+			return specialMapping(e, mapping)
+		}
+	}
+	if unconditional != nil {
+		return specialMapping(unconditional, mapping)
+	}
+
+	var simple map[rune]rune
+	switch mapping {
+	case Upper:
+		simple = m.upper
+	case Lower:
+		simple = m.lower
+	case Title:
+		simple = m.title
+		// Characters without a titlecase mapping map to their uppercase.
+		if _, ok := simple[c]; !ok {
+			simple = m.upper
+		}
+	}
+	if t, ok := simple[c]; ok {
+		return []rune{t}
+	}
+	return []rune{c}
+}
+
+func specialMapping(e *property.SpecialCasingEntry, mapping Mapping) []rune {
+	switch mapping {
+	case Upper:
+		return e.Upper
+	case Title:
+		return e.Title
+	}
+	return e.Lower
+}
+
+// fold returns the full case folding of a character. For Turkic languages, the mappings whose status is T take
+// precedence.
+func (m *Mapper) fold(c rune, lang Language) []rune {
+	if lang.turkic() {
+		if t, ok := m.cf.Turkic[c]; ok {
+			return []rune{t}
+		}
+	}
+	if f, ok := m.cf.Full[c]; ok {
+		return f
+	}
+	return []rune{c}
+}
+
+// satisfy reports whether all the conditions are satisfied at the position i. See Table 3-17 Context Specification
+// for Casing in [Unicode] for the casing contexts.
+func (m *Mapper) satisfy(rs []rune, i int, conditions []string, lang Language) bool {
+	for _, cond := range conditions {
+		var ok bool
+		switch cond {
+		case "Final_Sigma":
+			ok = m.finalSigma(rs, i)
+		case "After_Soft_Dotted":
+			ok = m.afterSoftDotted(rs, i)
+		case "More_Above":
+			ok = m.moreAbove(rs, i)
+		case "Before_Dot":
+			ok = m.beforeDot(rs, i)
+		case "Not_Before_Dot":
+			ok = !m.beforeDot(rs, i)
+		case "After_I":
+			ok = m.afterI(rs, i)
+		default:
+			ok = Language(strings.ToLower(cond)) == lang
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// finalSigma matches `\p{cased} (\p{Case_Ignorable})* C` and doesn't match `C (\p{Case_Ignorable})* \p{cased}`.
+func (m *Mapper) finalSigma(rs []rune, i int) bool {
+	j := i - 1
+	for j >= 0 && m.ignorable.contain(rs[j]) {
+		j--
+	}
+	if j < 0 || !m.cased.contain(rs[j]) {
+		return false
+	}
+	j = i + 1
+	for j < len(rs) && m.ignorable.contain(rs[j]) {
+		j++
+	}
+	return j >= len(rs) || !m.cased.contain(rs[j])
+}
+
+// afterSoftDotted reports whether a Soft_Dotted character precedes the position i with no intervening character
+// whose Canonical_Combining_Class is 0 or 230.
+func (m *Mapper) afterSoftDotted(rs []rune, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		if m.softDotted.contain(rs[j]) {
+			return true
+		}
+		if ccc := m.ccc.lookup(rs[j]); ccc == 0 || ccc == 230 {
+			return false
+		}
+	}
+	return false
+}
+
+// moreAbove reports whether a character whose Canonical_Combining_Class is 230 follows the position i with no
+// intervening character whose Canonical_Combining_Class is 0.
+func (m *Mapper) moreAbove(rs []rune, i int) bool {
+	for j := i + 1; j < len(rs); j++ {
+		switch m.ccc.lookup(rs[j]) {
+		case 230:
+			return true
+		case 0:
+			return false
+		}
+	}
+	return false
+}
+
+// beforeDot reports whether U+0307 follows the position i with no intervening character whose
+// Canonical_Combining_Class is 0 or 230.
+func (m *Mapper) beforeDot(rs []rune, i int) bool {
+	for j := i + 1; j < len(rs); j++ {
+		if rs[j] == combiningDotAbove {
+			return true
+		}
+		if ccc := m.ccc.lookup(rs[j]); ccc == 0 || ccc == 230 {
+			return false
+		}
+	}
+	return false
+}
+
+// afterI reports whether U+0049 precedes the position i with no intervening character whose
+// Canonical_Combining_Class is 0 or 230.
+func (m *Mapper) afterI(rs []rune, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		if rs[j] == latinCapitalI {
+			return true
+		}
+		if ccc := m.ccc.lookup(rs[j]); ccc == 0 || ccc == 230 {
+			return false
+		}
+	}
+	return false
+}
+
+// rangeTable maps code points to values. The table is sorted by code points so that it can be searched in
+// logarithmic time.
+type rangeTable []*valueRange
+
+type valueRange struct {
+	from  rune
+	to    rune
+	value int
+}
+
+// newRangeTable makes a table mapping code points in the ranges to a value.
+func newRangeTable(cps []*property.CodePointRange, value int) rangeTable {
+	t := make(rangeTable, 0, len(cps))
+	for _, cp := range cps {
+		from, to := cp.Range()
+		t = append(t, &valueRange{
+			from:  from,
+			to:    to,
+			value: value,
+		})
+	}
+	sortRangeTable(t)
+	return t
+}
+
+func sortRangeTable(t rangeTable) {
+	sort.Slice(t, func(i, j int) bool {
+		return t[i].from < t[j].from
+	})
+}
+
+func (t rangeTable) lookup(c rune) int {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].to >= c
+	})
+	if i < len(t) && t[i].from <= c {
+		return t[i].value
+	}
+	return 0
+}
+
+func (t rangeTable) contain(c rune) bool {
+	return t.lookup(c) != 0
+}
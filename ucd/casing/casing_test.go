@@ -0,0 +1,223 @@
+package casing
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+	"github.com/nihei9/ucdx/ucd/segment"
+)
+
+const testUnicodeData = `
+00DF;LATIN SMALL LETTER SHARP S;Ll;0;L;;;;;N;;;;;
+0130;LATIN CAPITAL LETTER I WITH DOT ABOVE;Lu;0;L;0049 0307;;;;N;LATIN CAPITAL LETTER I DOT;;;0069;
+0131;LATIN SMALL LETTER DOTLESS I;Ll;0;L;;;;;N;;;0049;;0049
+01C4;LATIN CAPITAL LETTER DZ WITH CARON;Lu;0;L;<compat> 0044 017D;;;;N;LATIN CAPITAL LETTER D Z HACEK;;;01C6;01C5
+01C5;LATIN CAPITAL LETTER D WITH SMALL LETTER Z WITH CARON;Lt;0;L;<compat> 0044 017E;;;;N;LATIN LETTER CAPITAL D SMALL Z HACEK;;01C4;01C6;01C5
+01C6;LATIN SMALL LETTER DZ WITH CARON;Ll;0;L;<compat> 0064 017E;;;;N;LATIN SMALL LETTER D Z HACEK;;01C4;;01C5
+0300;COMBINING GRAVE ACCENT;Mn;230;NSM;;;;;N;NON-SPACING GRAVE;;;;
+0307;COMBINING DOT ABOVE;Mn;230;NSM;;;;;N;NON-SPACING DOT ABOVE;;;;
+0391;GREEK CAPITAL LETTER ALPHA;Lu;0;L;;;;;N;;;;03B1;
+0394;GREEK CAPITAL LETTER DELTA;Lu;0;L;;;;;N;;;;03B4;
+039F;GREEK CAPITAL LETTER OMICRON;Lu;0;L;;;;;N;;;;03BF;
+03A3;GREEK CAPITAL LETTER SIGMA;Lu;0;L;;;;;N;;;;03C3;
+03B1;GREEK SMALL LETTER ALPHA;Ll;0;L;;;;;N;;;0391;;0391
+03B4;GREEK SMALL LETTER DELTA;Ll;0;L;;;;;N;;;0394;;0394
+03BF;GREEK SMALL LETTER OMICRON;Ll;0;L;;;;;N;;;039F;;039F
+03C2;GREEK SMALL LETTER FINAL SIGMA;Ll;0;L;;;;;N;;;03A3;;03A3
+03C3;GREEK SMALL LETTER SIGMA;Ll;0;L;;;;;N;;;03A3;;03A3
+`
+
+const testSpecialCasing = `
+# Unconditional mappings
+00DF; 00DF; 0053 0073; 0053 0053; # LATIN SMALL LETTER SHARP S
+0130; 0069 0307; 0130; 0130; # LATIN CAPITAL LETTER I WITH DOT ABOVE
+
+# Conditional mappings
+03A3; 03C2; 03A3; 03A3; Final_Sigma; # GREEK CAPITAL LETTER SIGMA
+
+# Language-Sensitive Mappings
+0049; 0069 0307; 0049; 0049; lt More_Above; # LATIN CAPITAL LETTER I
+0130; 0069; 0130; 0130; tr; # LATIN CAPITAL LETTER I WITH DOT ABOVE
+0307; ; 0307; 0307; tr After_I; # COMBINING DOT ABOVE
+0049; 0131; 0049; 0049; tr Not_Before_Dot; # LATIN CAPITAL LETTER I
+0069; 0069; 0130; 0130; tr; # LATIN SMALL LETTER I
+`
+
+const testCaseFolding = `
+0049; C; 0069; # LATIN CAPITAL LETTER I
+0049; T; 0131; # LATIN CAPITAL LETTER I
+0053; C; 0073; # LATIN CAPITAL LETTER S
+00DF; F; 0073 0073; # LATIN SMALL LETTER SHARP S
+0130; F; 0069 0307; # LATIN CAPITAL LETTER I WITH DOT ABOVE
+0130; T; 0069; # LATIN CAPITAL LETTER I WITH DOT ABOVE
+03A3; C; 03C3; # GREEK CAPITAL LETTER SIGMA
+03C2; C; 03C3; # GREEK SMALL LETTER FINAL SIGMA
+`
+
+const testDerivedCoreProperties = `
+0041..005A    ; Cased # L&  [26] LATIN CAPITAL LETTER A..LATIN CAPITAL LETTER Z
+0061..007A    ; Cased # L&  [26] LATIN SMALL LETTER A..LATIN SMALL LETTER Z
+00DF          ; Cased # L&       LATIN SMALL LETTER SHARP S
+0130..0131    ; Cased # L&   [2] LATIN CAPITAL LETTER I WITH DOT ABOVE..LATIN SMALL LETTER DOTLESS I
+01C4..01C6    ; Cased # L&   [3] LATIN CAPITAL LETTER DZ WITH CARON..LATIN SMALL LETTER DZ WITH CARON
+0391..03A9    ; Cased # L&  [19] GREEK CAPITAL LETTER ALPHA..GREEK CAPITAL LETTER OMEGA
+03B1..03C9    ; Cased # L&  [25] GREEK SMALL LETTER ALPHA..GREEK SMALL LETTER OMEGA
+0027          ; Case_Ignorable # Po       APOSTROPHE
+0300..036F    ; Case_Ignorable # Mn [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+`
+
+const testPropList = `
+0069..006A    ; Soft_Dotted # L&   [2] LATIN SMALL LETTER I..LATIN SMALL LETTER J
+`
+
+const testWordBreakProperty = `
+0027          ; Single_Quote # Po       APOSTROPHE
+0041..005A    ; ALetter # L&  [26] LATIN CAPITAL LETTER A..LATIN CAPITAL LETTER Z
+0061..007A    ; ALetter # L&  [26] LATIN SMALL LETTER A..LATIN SMALL LETTER Z
+00DF          ; ALetter # L&       LATIN SMALL LETTER SHARP S
+01C4..01C6    ; ALetter # L&   [3] LATIN CAPITAL LETTER DZ WITH CARON..LATIN SMALL LETTER DZ WITH CARON
+0300..036F    ; Extend # Mn [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+`
+
+// testASCIILetters returns the records of UnicodeData.txt for the ASCII letters.
+func testASCIILetters() string {
+	var b strings.Builder
+	for c := 'A'; c <= 'Z'; c++ {
+		fmt.Fprintf(&b, "%04X;LATIN CAPITAL LETTER %c;Lu;0;L;;;;;N;;;;%04X;\n", c, c, c+0x20)
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		fmt.Fprintf(&b, "%04X;LATIN SMALL LETTER %c;Ll;0;L;;;;;N;;;%04X;;%04X\n", c, c-0x20, c-0x20, c-0x20)
+	}
+	return b.String()
+}
+
+func TestMapper(t *testing.T) {
+	ud, err := parser.ParseUnicodeData(strings.NewReader(testASCIILetters() + testUnicodeData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc, err := parser.ParseSpecialCasing(strings.NewReader(testSpecialCasing))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cf, err := parser.ParseCaseFolding(strings.NewReader(testCaseFolding))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dcp, err := parser.ParseDerivedCoreProperties(strings.NewReader(testDerivedCoreProperties))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := parser.ParsePropList(strings.NewReader(testPropList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wbp, err := parser.ParseWordBreakProperty(strings.NewReader(testWordBreakProperty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emoji, err := parser.ParseEmojiData(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMapper(ud, sc, cf, dcp, pl, segment.NewWordBreaker(wbp, emoji))
+
+	tests := []struct {
+		src      string
+		mapping  Mapping
+		lang     Language
+		expected string
+	}{
+		// A full mapping may map a character to multiple characters.
+		{
+			src:      "stra\u00DFe",
+			mapping:  Upper,
+			expected: "STRASSE",
+		},
+		// Final_Sigma
+		{
+			src:      "\u039F\u0394\u039F\u03A3 \u03A3\u0391",
+			mapping:  Lower,
+			expected: "\u03BF\u03B4\u03BF\u03C2 \u03C3\u03B1",
+		},
+		{
+			src:      "\u03A3",
+			mapping:  Lower,
+			expected: "\u03C3",
+		},
+		// The language-specific mappings apply only when the language is specified.
+		{
+			src:      "i",
+			mapping:  Upper,
+			expected: "I",
+		},
+		{
+			src:      "i",
+			mapping:  Upper,
+			lang:     LanguageTurkish,
+			expected: "\u0130",
+		},
+		// Not_Before_Dot and After_I
+		{
+			src:      "I I\u0307",
+			mapping:  Lower,
+			lang:     LanguageTurkish,
+			expected: "\u0131 i",
+		},
+		{
+			src:      "I\u0307",
+			mapping:  Lower,
+			expected: "i\u0307",
+		},
+		// More_Above
+		{
+			src:      "I\u0300 I",
+			mapping:  Lower,
+			lang:     LanguageLithuanian,
+			expected: "i\u0307\u0300 i",
+		},
+		// The first cased character of each word is mapped to its titlecase.
+		{
+			src:      "can't STOP \u01C6emal",
+			mapping:  Title,
+			expected: "Can't Stop \u01C5emal",
+		},
+		{
+			src:      "\u00DFa",
+			mapping:  Title,
+			expected: "Ssa",
+		},
+		{
+			src:      "Stra\u00DFe",
+			mapping:  Fold,
+			expected: "strasse",
+		},
+		{
+			src:      "I\u0130",
+			mapping:  Fold,
+			lang:     LanguageTurkish,
+			expected: "\u0131i",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+q %v %v", tt.src, tt.mapping, tt.lang), func(t *testing.T) {
+			actual := m.String(tt.src, tt.mapping, tt.lang)
+			if actual != tt.expected {
+				t.Fatalf("unexpected result: want: %+q, got: %+q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	for _, id := range []string{"", "tr", "LT", "az"} {
+		if _, err := ParseLanguage(id); err != nil {
+			t.Fatalf("%q must be supported: %v", id, err)
+		}
+	}
+	if _, err := ParseLanguage("ja"); err == nil {
+		t.Fatalf("ja must not be supported")
+	}
+}
@@ -0,0 +1,285 @@
+// Package charclass formats sets of code points as character classes of regular expressions.
+package charclass
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Flavor is a syntax of regular expressions.
+type Flavor string
+
+const (
+	// Go is the syntax of the regexp package of Go.
+	Go Flavor = "go"
+	// RE2 is the syntax of RE2.
+	RE2 Flavor = "re2"
+	// PCRE is the syntax of PCRE in UTF mode.
+	PCRE Flavor = "pcre"
+	// JavaScript is the syntax of JavaScript. The classes need the `u` flag unless they are formatted for UTF-16.
+	JavaScript Flavor = "js"
+	// Java is the syntax of java.util.regex.
+	Java Flavor = "java"
+	// POSIXBracket is a POSIX bracket expression. It consists of literal characters since the syntax has no
+	// escapes.
+	POSIXBracket Flavor = "posix-bracket"
+)
+
+var flavors = []Flavor{
+	Go,
+	RE2,
+	PCRE,
+	JavaScript,
+	Java,
+	POSIXBracket,
+}
+
+// Flavors returns the supported flavors.
+func Flavors() []Flavor {
+	return append([]Flavor{}, flavors...)
+}
+
+// ParseFlavor returns a flavor corresponding to a name such as `go`.
+func ParseFlavor(name string) (Flavor, error) {
+	for _, f := range flavors {
+		if strings.EqualFold(name, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown flavor: %v", name)
+}
+
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+	maxBMP       = 0xFFFF
+)
+
+// Format returns a character class matching sorted and merged code point ranges.
+//
+// The flavors matching UTF-8 text, which are Go, RE2, PCRE, and POSIXBracket, exclude the surrogate code points since
+// UTF-8 can't encode them. When `utf16` is true, JavaScript classes match UTF-16 code units without the `u` flag, and
+// a supplementary code point is matched as its surrogate pair; such a class is a group of alternatives.
+func Format(cps []*property.CodePointRange, f Flavor, utf16 bool) (string, error) {
+	if utf16 && f != JavaScript {
+		return "", fmt.Errorf("%v doesn't need UTF-16 classes since it matches code points", f)
+	}
+
+	switch f {
+	case Go, RE2, PCRE, Java:
+		if f != Java {
+			cps = excludeSurrogates(cps)
+		}
+		if len(cps) == 0 {
+			return `[^\x{0000}-\x{10FFFF}]`, nil
+		}
+		return formatClass(cps, func(c rune) string {
+			return fmt.Sprintf(`\x{%04X}`, c)
+		}), nil
+	case JavaScript:
+		if utf16 {
+			return formatUTF16Class(cps), nil
+		}
+		if len(cps) == 0 {
+			return "[]", nil
+		}
+		return formatClass(cps, func(c rune) string {
+			return fmt.Sprintf(`\u{%04X}`, c)
+		}), nil
+	case POSIXBracket:
+		return formatPOSIXBracket(excludeSurrogates(cps))
+	}
+	return "", fmt.Errorf("unknown flavor: %v", f)
+}
+
+func formatClass(cps []*property.CodePointRange, escape func(c rune) string) string {
+	var b strings.Builder
+	fmt.Fprint(&b, "[")
+	for _, cp := range cps {
+		from, to := cp.Range()
+		fmt.Fprint(&b, escape(from))
+		if to > from {
+			fmt.Fprint(&b, "-", escape(to))
+		}
+	}
+	fmt.Fprint(&b, "]")
+	return b.String()
+}
+
+// formatUTF16Class returns a JavaScript pattern matching code points as UTF-16 code units. The BMP code points are
+// matched by a class, and the supplementary ones by alternatives of a high surrogate followed by a class of low
+// surrogates.
+func formatUTF16Class(cps []*property.CodePointRange) string {
+	escape := func(c rune) string {
+		return fmt.Sprintf(`\u%04X`, c)
+	}
+
+	var bmp []*property.CodePointRange
+	var alts []string
+	// highs holds the ranges of low surrogates following each high surrogate, in ascending order of the high ones.
+	var highs []rune
+	lows := map[rune][]*property.CodePointRange{}
+	for _, cp := range cps {
+		from, to := cp.Range()
+		if from <= maxBMP {
+			bmpTo := to
+			if bmpTo > maxBMP {
+				bmpTo = maxBMP
+			}
+			bmp = append(bmp, property.NewCodePointRange(from, bmpTo))
+			from = maxBMP + 1
+		}
+		for c := from; c <= to; {
+			hi, lo := surrogatePair(c)
+			// The last code point sharing the high surrogate.
+			last := c + (0xDFFF - lo)
+			if last > to {
+				last = to
+			}
+			_, lastLo := surrogatePair(last)
+			if _, ok := lows[hi]; !ok {
+				highs = append(highs, hi)
+			}
+			lows[hi] = append(lows[hi], property.NewCodePointRange(lo, lastLo))
+			c = last + 1
+		}
+	}
+
+	if len(bmp) > 0 {
+		alts = append(alts, formatClass(bmp, escape))
+	}
+	// Consecutive high surrogates followed by all the low surrogates are combined into a range.
+	for i := 0; i < len(highs); {
+		hi := highs[i]
+		if isFullLowRange(lows[hi]) {
+			j := i
+			for j+1 < len(highs) && highs[j+1] == highs[j]+1 && isFullLowRange(lows[highs[j+1]]) {
+				j++
+			}
+			alts = append(alts, formatClass([]*property.CodePointRange{property.NewCodePointRange(hi, highs[j])}, escape)+formatClass([]*property.CodePointRange{property.NewCodePointRange(0xDC00, 0xDFFF)}, escape))
+			i = j + 1
+			continue
+		}
+		alts = append(alts, escape(hi)+formatClass(lows[hi], escape))
+		i++
+	}
+
+	switch len(alts) {
+	case 0:
+		return "[]"
+	case 1:
+		return alts[0]
+	}
+	return "(?:" + strings.Join(alts, "|") + ")"
+}
+
+func surrogatePair(c rune) (rune, rune) {
+	c -= 0x10000
+	return 0xD800 + (c>>10)&0x3FF, 0xDC00 + c&0x3FF
+}
+
+func isFullLowRange(cps []*property.CodePointRange) bool {
+	if len(cps) != 1 {
+		return false
+	}
+	from, to := cps[0].Range()
+	return from == 0xDC00 && to == 0xDFFF
+}
+
+// posixSpecials are the characters having special meanings in bracket expressions depending on their positions.
+var posixSpecials = []rune{']', '^', '-'}
+
+// formatPOSIXBracket returns a bracket expression. `]` is placed first, `^` isn't placed first, and `-` is placed
+// last so that they mean themselves. Note that `-` precedes `^` when they are the only characters.
+func formatPOSIXBracket(cps []*property.CodePointRange) (string, error) {
+	if len(cps) == 0 {
+		return "", fmt.Errorf("a bracket expression can't match no characters")
+	}
+
+	contained := map[rune]bool{}
+	var rest []*property.CodePointRange
+	for _, cp := range cps {
+		from, to := cp.Range()
+		for _, s := range posixSpecials {
+			if s >= from && s <= to {
+				contained[s] = true
+			}
+		}
+		rest = append(rest, splitOut(from, to, posixSpecials)...)
+	}
+
+	var b strings.Builder
+	fmt.Fprint(&b, "[")
+	if contained[']'] {
+		fmt.Fprint(&b, "]")
+	}
+	for _, cp := range rest {
+		from, to := cp.Range()
+		b.WriteRune(from)
+		if to > from {
+			fmt.Fprint(&b, "-")
+			b.WriteRune(to)
+		}
+	}
+	if contained['^'] {
+		if b.Len() == 1 {
+			// `^` can't be first, so it follows `-`, or it is written as an equivalence class when it's the only
+			// character.
+			if !contained['-'] {
+				return "[[=^=]]", nil
+			}
+			fmt.Fprint(&b, "-")
+			contained['-'] = false
+		}
+		fmt.Fprint(&b, "^")
+	}
+	if contained['-'] {
+		fmt.Fprint(&b, "-")
+	}
+	fmt.Fprint(&b, "]")
+	return b.String(), nil
+}
+
+// splitOut returns ranges made by removing code points from a range.
+func splitOut(from, to rune, removed []rune) []*property.CodePointRange {
+	rs := []*property.CodePointRange{property.NewCodePointRange(from, to)}
+	for _, c := range removed {
+		var next []*property.CodePointRange
+		for _, r := range rs {
+			f, t := r.Range()
+			if c < f || c > t {
+				next = append(next, r)
+				continue
+			}
+			if f < c {
+				next = append(next, property.NewCodePointRange(f, c-1))
+			}
+			if c < t {
+				next = append(next, property.NewCodePointRange(c+1, t))
+			}
+		}
+		rs = next
+	}
+	// Removing the characters may break the order of the ranges, which doesn't matter in bracket expressions.
+	return rs
+}
+
+func excludeSurrogates(cps []*property.CodePointRange) []*property.CodePointRange {
+	var rs []*property.CodePointRange
+	for _, cp := range cps {
+		from, to := cp.Range()
+		if to < surrogateMin || from > surrogateMax {
+			rs = append(rs, cp)
+			continue
+		}
+		if from < surrogateMin {
+			rs = append(rs, property.NewCodePointRange(from, surrogateMin-1))
+		}
+		if to > surrogateMax {
+			rs = append(rs, property.NewCodePointRange(surrogateMax+1, to))
+		}
+	}
+	return rs
+}
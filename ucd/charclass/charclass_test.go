@@ -0,0 +1,150 @@
+package charclass
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+func TestFormat(t *testing.T) {
+	cps := []*property.CodePointRange{
+		property.NewCodePointRange(0x2D, 0x2D),
+		property.NewCodePointRange(0x41, 0x5A),
+		property.NewCodePointRange(0xD000, 0xD900),
+		property.NewCodePointRange(0x1F600, 0x1F64F),
+	}
+
+	tests := []struct {
+		flavor   Flavor
+		utf16    bool
+		expected string
+	}{
+		{
+			flavor:   Go,
+			expected: `[\x{002D}\x{0041}-\x{005A}\x{D000}-\x{D7FF}\x{1F600}-\x{1F64F}]`,
+		},
+		{
+			flavor:   Java,
+			expected: `[\x{002D}\x{0041}-\x{005A}\x{D000}-\x{D900}\x{1F600}-\x{1F64F}]`,
+		},
+		{
+			flavor:   JavaScript,
+			expected: `[\u{002D}\u{0041}-\u{005A}\u{D000}-\u{D900}\u{1F600}-\u{1F64F}]`,
+		},
+		{
+			flavor:   JavaScript,
+			utf16:    true,
+			expected: `(?:[\u002D\u0041-\u005A\uD000-\uD900]|\uD83D[\uDE00-\uDE4F])`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.flavor), func(t *testing.T) {
+			class, err := Format(cps, tt.flavor, tt.utf16)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if class != tt.expected {
+				t.Fatalf("unexpected class: want: %v, got: %v", tt.expected, class)
+			}
+		})
+	}
+}
+
+func TestFormat_GoRegexp(t *testing.T) {
+	cps := []*property.CodePointRange{
+		property.NewCodePointRange(0x41, 0x5A),
+		property.NewCodePointRange(0x10000, 0x10FFFF),
+	}
+	class, err := Format(cps, Go, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	re := regexp.MustCompile("^" + class + "$")
+	for _, c := range []rune{0x41, 0x5A, 0x10000, 0x10FFFF} {
+		if !re.MatchString(string(c)) {
+			t.Errorf("%v must match U+%04X", class, c)
+		}
+	}
+	for _, c := range []rune{0x40, 0x5B, 0xFFFF} {
+		if re.MatchString(string(c)) {
+			t.Errorf("%v must not match U+%04X", class, c)
+		}
+	}
+
+	empty, err := Format(nil, Go, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regexp.MustCompile(empty).MatchString("a") {
+		t.Errorf("%v must match no characters", empty)
+	}
+}
+
+func TestFormat_UTF16(t *testing.T) {
+	cps := []*property.CodePointRange{
+		// U+10000..U+107FF are the high surrogates U+D800..U+D801 followed by all the low surrogates.
+		property.NewCodePointRange(0x10000, 0x107FF),
+		property.NewCodePointRange(0x10800, 0x10801),
+	}
+	class, err := Format(cps, JavaScript, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `(?:[\uD800-\uD801][\uDC00-\uDFFF]|\uD802[\uDC00-\uDC01])`
+	if class != expected {
+		t.Fatalf("unexpected class: want: %v, got: %v", expected, class)
+	}
+
+	if _, err := Format(cps, Go, true); err == nil {
+		t.Fatalf("an error must occur")
+	}
+}
+
+func TestFormat_POSIXBracket(t *testing.T) {
+	tests := []struct {
+		caption  string
+		cps      []*property.CodePointRange
+		expected string
+	}{
+		{
+			caption: "special characters",
+			cps: []*property.CodePointRange{
+				property.NewCodePointRange('-', '-'),
+				property.NewCodePointRange('A', 'Z'),
+				property.NewCodePointRange(']', '^'),
+			},
+			expected: "[]A-Z^-]",
+		},
+		{
+			caption: "only `^` and `-`",
+			cps: []*property.CodePointRange{
+				property.NewCodePointRange('-', '-'),
+				property.NewCodePointRange('^', '^'),
+			},
+			expected: "[-^]",
+		},
+		{
+			caption: "only `^`",
+			cps: []*property.CodePointRange{
+				property.NewCodePointRange('^', '^'),
+			},
+			expected: "[[=^=]]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caption, func(t *testing.T) {
+			class, err := Format(tt.cps, POSIXBracket, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if class != tt.expected {
+				t.Fatalf("unexpected class: want: %v, got: %v", tt.expected, class)
+			}
+		})
+	}
+
+	if _, err := Format(nil, POSIXBracket, false); err == nil {
+		t.Fatalf("an error must occur")
+	}
+}
@@ -0,0 +1,161 @@
+package conformance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/bidi"
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+// BidiFailure is a test case that the Unicode Bidirectional Algorithm failed. `Source` is the input in the notation
+// of the test data file, which is a sequence of either Bidi_Class values or code points. Levels of -1 mean
+// characters removed by rule X9.
+type BidiFailure struct {
+	Line                   int            `json:"line"`
+	Source                 string         `json:"source"`
+	Direction              bidi.Direction `json:"direction"`
+	ExpectedParagraphLevel int            `json:"expected_paragraph_level"`
+	ExpectedLevels         []int          `json:"expected_levels"`
+	ExpectedReorder        []int          `json:"expected_reorder"`
+	ActualParagraphLevel   int            `json:"actual_paragraph_level"`
+	ActualLevels           []int          `json:"actual_levels"`
+	ActualReorder          []int          `json:"actual_reorder"`
+}
+
+func (f *BidiFailure) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "line %v: %v (%v)\n", f.Line, f.Source, f.Direction)
+	fmt.Fprintf(&b, "  expected: %v\n", formatBidiResult(f.ExpectedParagraphLevel, f.ExpectedLevels, f.ExpectedReorder))
+	fmt.Fprintf(&b, "  actual:   %v", formatBidiResult(f.ActualParagraphLevel, f.ActualLevels, f.ActualReorder))
+	return b.String()
+}
+
+// formatBidiResult formats a result like `paragraph level: 1, levels: x 1, reorder: 1`. BidiTest.txt doesn't
+// specify paragraph levels, so a negative paragraph level is omitted.
+func formatBidiResult(level int, levels []int, reorder []int) string {
+	var b strings.Builder
+	if level >= 0 {
+		fmt.Fprintf(&b, "paragraph level: %v, ", level)
+	}
+	fmt.Fprint(&b, "levels:")
+	for _, l := range levels {
+		if l < 0 {
+			fmt.Fprint(&b, " x")
+		} else {
+			fmt.Fprintf(&b, " %v", l)
+		}
+	}
+	fmt.Fprint(&b, ", reorder:")
+	for _, i := range reorder {
+		fmt.Fprintf(&b, " %v", i)
+	}
+	return b.String()
+}
+
+// BidiResult is a result of a conformance test of the Unicode Bidirectional Algorithm. A test case of BidiTest.txt
+// counts once for each paragraph direction it applies to.
+type BidiResult struct {
+	Cases    int            `json:"cases"`
+	Passed   int            `json:"passed"`
+	Failures []*BidiFailure `json:"failures"`
+}
+
+var bidiDirections = map[int]bidi.Direction{
+	parser.BidiDirectionLTR:  bidi.LeftToRight,
+	parser.BidiDirectionRTL:  bidi.RightToLeft,
+	parser.BidiDirectionAuto: bidi.Auto,
+}
+
+// RunBidi checks that the Unicode Bidirectional Algorithm resolves the same levels and visual orders as the test
+// cases of BidiTest.txt.
+func RunBidi(cases []*parser.BidiTestCase) (*BidiResult, error) {
+	result := &BidiResult{}
+	for _, c := range cases {
+		classes := make([]bidi.Class, len(c.Classes))
+		for i, sym := range c.Classes {
+			cls, err := bidi.ParseClass(sym.String())
+			if err != nil {
+				return nil, fmt.Errorf("%v: line %v", err, c.Line)
+			}
+			classes[i] = cls
+		}
+		var src strings.Builder
+		for i, sym := range c.Classes {
+			if i > 0 {
+				fmt.Fprint(&src, " ")
+			}
+			fmt.Fprint(&src, strings.ToUpper(sym.String()))
+		}
+		for _, d := range c.Directions {
+			dir := bidiDirections[d]
+			p := bidi.ResolveClasses(classes, dir)
+			result.Cases++
+			order := p.VisualOrder()
+			if equalInts(p.Levels, c.Levels) && equalInts(order, c.Reorder) {
+				result.Passed++
+				continue
+			}
+			result.Failures = append(result.Failures, &BidiFailure{
+				Line:                   c.Line,
+				Source:                 src.String(),
+				Direction:              dir,
+				ExpectedParagraphLevel: -1,
+				ExpectedLevels:         c.Levels,
+				ExpectedReorder:        c.Reorder,
+				ActualParagraphLevel:   -1,
+				ActualLevels:           p.Levels,
+				ActualReorder:          order,
+			})
+		}
+	}
+	return result, nil
+}
+
+// RunBidiCharacter checks that a resolver resolves the same paragraph levels, levels, and visual orders as the test
+// cases of BidiCharacterTest.txt.
+func RunBidiCharacter(r *bidi.Resolver, cases []*parser.BidiCharacterTestCase) *BidiResult {
+	result := &BidiResult{
+		Cases: len(cases),
+	}
+	for _, c := range cases {
+		dir := bidiDirections[c.Direction]
+		p := r.Resolve(c.Runes, dir)
+		order := p.VisualOrder()
+		if p.Level == c.ParagraphLevel && equalInts(p.Levels, c.Levels) && equalInts(order, c.Reorder) {
+			result.Passed++
+			continue
+		}
+		var src strings.Builder
+		for i, cp := range c.Runes {
+			if i > 0 {
+				fmt.Fprint(&src, " ")
+			}
+			fmt.Fprintf(&src, "%04X", cp)
+		}
+		result.Failures = append(result.Failures, &BidiFailure{
+			Line:                   c.Line,
+			Source:                 src.String(),
+			Direction:              dir,
+			ExpectedParagraphLevel: c.ParagraphLevel,
+			ExpectedLevels:         c.Levels,
+			ExpectedReorder:        c.Reorder,
+			ActualParagraphLevel:   p.Level,
+			ActualLevels:           p.Levels,
+			ActualReorder:          order,
+		})
+	}
+	return result
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
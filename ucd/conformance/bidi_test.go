@@ -0,0 +1,99 @@
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/bidi"
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+func TestRunBidi(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtBidiTest)
+
+	f, err := os.Open(filepath.Join(dirPath, ucd.TxtBidiTest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cases, err := parser.ParseBidiTest(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("%v has no test cases", ucd.TxtBidiTest)
+	}
+
+	result, err := RunBidi(cases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBidiResult(t, result)
+}
+
+func TestRunBidiCharacter(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtUnicodeData, ucd.TxtBidiBrackets, ucd.TxtBidiMirroring, ucd.TxtBidiCharacterTest)
+
+	var r *bidi.Resolver
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtUnicodeData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		ud, err := parser.ParseUnicodeData(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := os.Open(filepath.Join(dirPath, ucd.TxtBidiBrackets))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer g.Close()
+		brackets, err := parser.ParseBidiBrackets(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := os.Open(filepath.Join(dirPath, ucd.TxtBidiMirroring))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer h.Close()
+		mirroring, err := parser.ParseBidiMirroring(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = bidi.NewResolver(ud, brackets, mirroring)
+	}
+
+	f, err := os.Open(filepath.Join(dirPath, ucd.TxtBidiCharacterTest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cases, err := parser.ParseBidiCharacterTest(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("%v has no test cases", ucd.TxtBidiCharacterTest)
+	}
+
+	testBidiResult(t, RunBidiCharacter(r, cases))
+}
+
+func testBidiResult(t *testing.T, result *BidiResult) {
+	t.Helper()
+
+	for i, f := range result.Failures {
+		if i >= 100 {
+			t.Fatalf("too many failures: %v", len(result.Failures))
+		}
+		t.Error(f)
+	}
+}
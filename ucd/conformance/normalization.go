@@ -0,0 +1,120 @@
+// Package conformance runs the conformance tests the UCD provides against the implementations in ucdx.
+package conformance
+
+import (
+	"fmt"
+
+	"github.com/nihei9/ucdx/ucd/normalize"
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+// NormalizationFailure is a failed invariant of NormalizationTest.txt. `Column` is a 1-origin column number, the
+// same as the notation of NormalizationTest.txt (c1..c5). `Line` is 0 when the failure comes from the invariant
+// for the code points not listed in Part 1.
+type NormalizationFailure struct {
+	Line     int            `json:"line"`
+	Form     normalize.Form `json:"form"`
+	Column   int            `json:"column"`
+	Source   []rune         `json:"source"`
+	Expected []rune         `json:"expected"`
+	Actual   []rune         `json:"actual"`
+}
+
+func (f *NormalizationFailure) String() string {
+	if f.Line == 0 {
+		return fmt.Sprintf("%v(%04X) must be %04X, but got %04X", f.Form, f.Source, f.Expected, f.Actual)
+	}
+	return fmt.Sprintf("line %v: %v(c%v) must be %04X, but got %04X (c%v = %04X)", f.Line, f.Form, f.Column, f.Expected, f.Actual, f.Column, f.Source)
+}
+
+type NormalizationResult struct {
+	Cases    int                     `json:"cases"`
+	Failures []*NormalizationFailure `json:"failures"`
+}
+
+// normalizationInvariants lists the invariants NormalizationTest.txt defines. Each element maps a column to be
+// expected to the columns whose normalized forms must be equal to it.
+var normalizationInvariants = []struct {
+	form     normalize.Form
+	expected int
+	sources  []int
+}{
+	{form: normalize.NFC, expected: 2, sources: []int{1, 2, 3}},
+	{form: normalize.NFC, expected: 4, sources: []int{4, 5}},
+	{form: normalize.NFD, expected: 3, sources: []int{1, 2, 3}},
+	{form: normalize.NFD, expected: 5, sources: []int{4, 5}},
+	{form: normalize.NFKC, expected: 4, sources: []int{1, 2, 3, 4, 5}},
+	{form: normalize.NFKD, expected: 5, sources: []int{1, 2, 3, 4, 5}},
+}
+
+// RunNormalization checks that a normalizer satisfies all the invariants NormalizationTest.txt defines.
+func RunNormalization(n *normalize.Normalizer, cases []*parser.NormalizationTestCase) *NormalizationResult {
+	result := &NormalizationResult{
+		Cases: len(cases),
+	}
+
+	part1 := map[rune]bool{}
+	for _, c := range cases {
+		if c.Part == "Part1" && len(c.Columns[0]) == 1 {
+			part1[c.Columns[0][0]] = true
+		}
+
+		for _, inv := range normalizationInvariants {
+			expected := c.Columns[inv.expected-1]
+			for _, col := range inv.sources {
+				src := c.Columns[col-1]
+				actual := n.Runes(inv.form, src)
+				if !equalRunes(actual, expected) {
+					result.Failures = append(result.Failures, &NormalizationFailure{
+						Line:     c.Line,
+						Form:     inv.form,
+						Column:   col,
+						Source:   src,
+						Expected: expected,
+						Actual:   actual,
+					})
+				}
+			}
+		}
+	}
+
+	// NormalizationTest.txt:
+	// > For every code point X assigned in this version of Unicode that is not specifically listed in Part 1, the
+	// > following invariants must be true for all conformant implementations:
+	// >
+	// >   X == toNFC(X) == toNFD(X) == toNFKC(X) == toNFKD(X)
+	//
+	// We check all code points except surrogates because unassigned code points never change either.
+	for c := rune(0); c <= 0x10FFFF; c++ {
+		if part1[c] || (c >= 0xD800 && c <= 0xDFFF) {
+			continue
+		}
+		src := []rune{c}
+		for _, f := range []normalize.Form{normalize.NFC, normalize.NFD, normalize.NFKC, normalize.NFKD} {
+			actual := n.Runes(f, src)
+			if !equalRunes(actual, src) {
+				result.Failures = append(result.Failures, &NormalizationFailure{
+					Form:     f,
+					Column:   1,
+					Source:   src,
+					Expected: src,
+					Actual:   actual,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, c := range a {
+		if b[i] != c {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/normalize"
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+// testDataDirPath returns a directory containing local copies of the UCD's data files. The directory is specified
+// by the UCDX_TEST_DATA_DIR environment variable and defaults to ${HOME}/.ucdx/db, where the setup command saves
+// the data files. When the directory doesn't contain a required file, the test is skipped.
+func testDataDirPath(t *testing.T, dataFileNames ...string) string {
+	t.Helper()
+
+	dirPath := os.Getenv("UCDX_TEST_DATA_DIR")
+	if dirPath == "" {
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			t.Skip(err)
+		}
+		dirPath = filepath.Join(homeDirPath, ".ucdx", "db")
+	}
+	for _, name := range dataFileNames {
+		if _, err := os.Stat(filepath.Join(dirPath, name)); err != nil {
+			t.Skipf("%v is not found in %v; run `ucdx setup` or set UCDX_TEST_DATA_DIR", name, dirPath)
+		}
+	}
+	return dirPath
+}
+
+func TestRunNormalization(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtUnicodeData, ucd.TxtDerivedNormalizationProps, ucd.TxtNormalizationTest)
+
+	var n *normalize.Normalizer
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtUnicodeData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		ud, err := parser.ParseUnicodeData(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := os.Open(filepath.Join(dirPath, ucd.TxtDerivedNormalizationProps))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer g.Close()
+		dnp, err := parser.ParseDerivedNormalizationProps(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err = normalize.NewNormalizer(ud, dnp)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var cases []*parser.NormalizationTestCase
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtNormalizationTest))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		cases, err = parser.ParseNormalizationTest(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(cases) == 0 {
+		t.Fatal("NormalizationTest.txt has no test cases")
+	}
+
+	result := RunNormalization(n, cases)
+	for i, f := range result.Failures {
+		if i >= 100 {
+			t.Fatalf("too many failures: %v", len(result.Failures))
+		}
+		t.Error(f)
+	}
+}
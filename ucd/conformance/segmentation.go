@@ -0,0 +1,86 @@
+package conformance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+	"github.com/nihei9/ucdx/ucd/segment"
+)
+
+// SegmentationFailure is a test case that a breaker failed. `ExpectedRules` holds the rule numbers the test data
+// file shows, and `ActualRules` holds the numbers of the rules the breaker applied.
+type SegmentationFailure struct {
+	Line           int      `json:"line"`
+	Source         []rune   `json:"source"`
+	ExpectedBreaks []bool   `json:"expected_breaks"`
+	ExpectedRules  []string `json:"expected_rules"`
+	ActualBreaks   []bool   `json:"actual_breaks"`
+	ActualRules    []string `json:"actual_rules"`
+}
+
+func (f *SegmentationFailure) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "line %v:\n", f.Line)
+	fmt.Fprintf(&b, "  expected: %v\n", formatBreaks(f.Source, f.ExpectedBreaks, f.ExpectedRules))
+	fmt.Fprintf(&b, "  actual:   %v", formatBreaks(f.Source, f.ActualBreaks, f.ActualRules))
+	return b.String()
+}
+
+// formatBreaks formats a test case in the notation of the test data files like `÷ [0.2] 0020 × [9.0] 0308 ÷ [0.3]`.
+func formatBreaks(rs []rune, breaks []bool, rules []string) string {
+	var b strings.Builder
+	for i, brk := range breaks {
+		if i > 0 {
+			fmt.Fprintf(&b, " %04X ", rs[i-1])
+		}
+		if brk {
+			fmt.Fprint(&b, "÷")
+		} else {
+			fmt.Fprint(&b, "×")
+		}
+		if rules != nil {
+			fmt.Fprintf(&b, " [%v]", rules[i])
+		}
+	}
+	return b.String()
+}
+
+type SegmentationResult struct {
+	Cases    int                    `json:"cases"`
+	Passed   int                    `json:"passed"`
+	Failures []*SegmentationFailure `json:"failures"`
+}
+
+// RunSegmentation checks that a breaker determines the same boundaries as the test cases.
+func RunSegmentation(b segment.Breaker, cases []*parser.BreakTestCase) *SegmentationResult {
+	result := &SegmentationResult{
+		Cases: len(cases),
+	}
+	for _, c := range cases {
+		bounds := b.Boundaries(c.Runes)
+		breaks := make([]bool, len(bounds))
+		rules := make([]string, len(bounds))
+		passed := len(bounds) == len(c.Breaks)
+		for i, bound := range bounds {
+			breaks[i] = bound.Break
+			rules[i] = bound.Rule.Number()
+			if passed && bound.Break != c.Breaks[i] {
+				passed = false
+			}
+		}
+		if passed {
+			result.Passed++
+			continue
+		}
+		result.Failures = append(result.Failures, &SegmentationFailure{
+			Line:           c.Line,
+			Source:         c.Runes,
+			ExpectedBreaks: c.Breaks,
+			ExpectedRules:  c.Rules,
+			ActualBreaks:   breaks,
+			ActualRules:    rules,
+		})
+	}
+	return result
+}
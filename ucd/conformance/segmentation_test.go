@@ -0,0 +1,170 @@
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/parser"
+	"github.com/nihei9/ucdx/ucd/segment"
+)
+
+func TestRunSegmentation_Grapheme(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtGraphemeBreakProperty, ucd.TxtEmojiData, ucd.TxtGraphemeBreakTest)
+
+	var b segment.Breaker
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtGraphemeBreakProperty))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		gbp, err := parser.ParseGraphemeBreakProperty(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := os.Open(filepath.Join(dirPath, ucd.TxtEmojiData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer g.Close()
+		emoji, err := parser.ParseEmojiData(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b = segment.NewGraphemeBreaker(gbp, emoji)
+	}
+
+	testRunSegmentation(t, b, filepath.Join(dirPath, ucd.TxtGraphemeBreakTest))
+}
+
+func TestRunSegmentation_Word(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtWordBreakProperty, ucd.TxtEmojiData, ucd.TxtWordBreakTest)
+
+	var b segment.Breaker
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtWordBreakProperty))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		wbp, err := parser.ParseWordBreakProperty(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := os.Open(filepath.Join(dirPath, ucd.TxtEmojiData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer g.Close()
+		emoji, err := parser.ParseEmojiData(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b = segment.NewWordBreaker(wbp, emoji)
+	}
+
+	testRunSegmentation(t, b, filepath.Join(dirPath, ucd.TxtWordBreakTest))
+}
+
+func TestRunSegmentation_Sentence(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtSentenceBreakProperty, ucd.TxtSentenceBreakTest)
+
+	var b segment.Breaker
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtSentenceBreakProperty))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		sbp, err := parser.ParseSentenceBreakProperty(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b = segment.NewSentenceBreaker(sbp)
+	}
+
+	testRunSegmentation(t, b, filepath.Join(dirPath, ucd.TxtSentenceBreakTest))
+}
+
+func TestRunSegmentation_Line(t *testing.T) {
+	dirPath := testDataDirPath(t, ucd.TxtLineBreak, ucd.TxtEastAsianWidth, ucd.TxtEmojiData, ucd.TxtUnicodeData, ucd.TxtLineBreakTest)
+
+	var b segment.Breaker
+	{
+		f, err := os.Open(filepath.Join(dirPath, ucd.TxtLineBreak))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		lb, err := parser.ParseLineBreak(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		e, err := os.Open(filepath.Join(dirPath, ucd.TxtEastAsianWidth))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer e.Close()
+		eaw, err := parser.ParseEastAsianWidth(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := os.Open(filepath.Join(dirPath, ucd.TxtEmojiData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer g.Close()
+		emoji, err := parser.ParseEmojiData(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := os.Open(filepath.Join(dirPath, ucd.TxtUnicodeData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer h.Close()
+		ud, err := parser.ParseUnicodeData(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b = segment.NewLineBreaker(lb, eaw, emoji, ud)
+	}
+
+	testRunSegmentation(t, b, filepath.Join(dirPath, ucd.TxtLineBreakTest))
+}
+
+func testRunSegmentation(t *testing.T, b segment.Breaker, testFilePath string) {
+	t.Helper()
+
+	f, err := os.Open(testFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cases, err := parser.ParseBreakTest(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("%v has no test cases", testFilePath)
+	}
+
+	result := RunSegmentation(b, cases)
+	for i, f := range result.Failures {
+		if i >= 100 {
+			t.Fatalf("too many failures: %v", len(result.Failures))
+		}
+		t.Error(f)
+	}
+}
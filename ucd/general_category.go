@@ -13,7 +13,7 @@ var generalCategoryGroups = map[property.PropertyValueSymbol][]property.Property
 	// Letter
 	"l": {"lu", "ll", "lt", "lm", "lo"},
 	// Mark
-	"m": {"mm", "mc", "me"},
+	"m": {"mn", "mc", "me"},
 	// Number
 	"n": {"nd", "nl", "no"},
 	// Punctuation
@@ -45,3 +45,14 @@ func lookupGCGroups(gc property.PropertyValueSymbol) []property.PropertyValueSym
 
 	return groups
 }
+
+// addGCGroupRanges adds the ranges of the General_Category groups to the ranges of the General_Category values.
+func addGCGroupRanges(ranges map[string][]*property.CodePointRange) {
+	for group, gcs := range generalCategoryGroups {
+		var cps []*property.CodePointRange
+		for _, gc := range gcs {
+			cps = append(cps, ranges[gc.String()]...)
+		}
+		ranges[group.String()] = mergeCodePointRanges(cps)
+	}
+}
@@ -0,0 +1,108 @@
+// Package gogen generates Go source code of tables made from the UCD.
+package gogen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/nihei9/ucdx/ucd"
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// maxRune16 is the maximum code point unicode.Range16 can hold.
+const maxRune16 = 0xFFFF
+
+// RangeTable is a `*unicode.RangeTable` variable to generate.
+type RangeTable struct {
+	// Name is the name of the variable.
+	Name string
+
+	// Source describes where the code points come from, such as a query. It's written in the doc comment of the
+	// variable.
+	Source string
+
+	// Ranges are sorted and merged code point ranges.
+	Ranges []*property.CodePointRange
+}
+
+// NewRangeTable returns a `*unicode.RangeTable` containing sorted and merged code point ranges. A range crossing
+// U+FFFF is split into R16 and R32.
+func NewRangeTable(cps []*property.CodePointRange) *unicode.RangeTable {
+	t := &unicode.RangeTable{}
+	for _, cp := range cps {
+		from, to := cp.Range()
+		if from <= maxRune16 {
+			hi := to
+			if hi > maxRune16 {
+				hi = maxRune16
+			}
+			t.R16 = append(t.R16, unicode.Range16{
+				Lo:     uint16(from),
+				Hi:     uint16(hi),
+				Stride: 1,
+			})
+			if hi <= unicode.MaxLatin1 {
+				t.LatinOffset++
+			}
+			from = maxRune16 + 1
+		}
+		if from <= to {
+			t.R32 = append(t.R32, unicode.Range32{
+				Lo:     uint32(from),
+				Hi:     uint32(to),
+				Stride: 1,
+			})
+		}
+	}
+	return t
+}
+
+// GenerateRangeTables returns gofmt'd Go source code declaring `*unicode.RangeTable` variables in a package.
+func GenerateRangeTables(pkg string, tables []*RangeTable) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name: %v", pkg)
+	}
+	declared := map[string]bool{}
+	for _, t := range tables {
+		if !token.IsIdentifier(t.Name) {
+			return nil, fmt.Errorf("invalid variable name: %v", t.Name)
+		}
+		if declared[t.Name] {
+			return nil, fmt.Errorf("variable %v is declared more than once", t.Name)
+		}
+		declared[t.Name] = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by ucdx gen go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %v\n\n", pkg)
+	fmt.Fprintf(&b, "import \"unicode\"\n")
+	for _, t := range tables {
+		rt := NewRangeTable(t.Ranges)
+		fmt.Fprintf(&b, "\n// %v is the set of code points `%v` selects in Unicode %v.\n", t.Name, t.Source, ucd.UnicodeVersion)
+		fmt.Fprintf(&b, "var %v = &unicode.RangeTable{\n", t.Name)
+		if len(rt.R16) > 0 {
+			fmt.Fprintf(&b, "R16: []unicode.Range16{\n")
+			for _, r := range rt.R16 {
+				fmt.Fprintf(&b, "{0x%04X, 0x%04X, %v},\n", r.Lo, r.Hi, r.Stride)
+			}
+			fmt.Fprintf(&b, "},\n")
+		}
+		if len(rt.R32) > 0 {
+			fmt.Fprintf(&b, "R32: []unicode.Range32{\n")
+			for _, r := range rt.R32 {
+				fmt.Fprintf(&b, "{0x%X, 0x%X, %v},\n", r.Lo, r.Hi, r.Stride)
+			}
+			fmt.Fprintf(&b, "},\n")
+		}
+		if rt.LatinOffset > 0 {
+			fmt.Fprintf(&b, "LatinOffset: %v,\n", rt.LatinOffset)
+		}
+		fmt.Fprintf(&b, "}\n")
+	}
+
+	return format.Source([]byte(b.String()))
+}
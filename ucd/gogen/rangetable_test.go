@@ -0,0 +1,90 @@
+package gogen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+func TestNewRangeTable(t *testing.T) {
+	cps := []*property.CodePointRange{
+		property.NewCodePointRange(0x41, 0x5A),
+		property.NewCodePointRange(0xC0, 0xD6),
+		property.NewCodePointRange(0xF8, 0x100),
+		property.NewCodePointRange(0xFFF0, 0x10010),
+		property.NewCodePointRange(0x1F600, 0x1F64F),
+	}
+	rt := NewRangeTable(cps)
+
+	if len(rt.R16) != 4 || len(rt.R32) != 2 {
+		t.Fatalf("unexpected number of ranges: R16: %v, R32: %v", len(rt.R16), len(rt.R32))
+	}
+	if rt.LatinOffset != 2 {
+		t.Fatalf("unexpected LatinOffset: want: 2, got: %v", rt.LatinOffset)
+	}
+	for c := rune(0); c <= 0x20000; c++ {
+		expected := false
+		for _, cp := range cps {
+			if cp.Contain(c) {
+				expected = true
+				break
+			}
+		}
+		if unicode.Is(rt, c) != expected {
+			t.Fatalf("unexpected result of U+%04X: want: %v", c, expected)
+		}
+	}
+}
+
+func TestGenerateRangeTables(t *testing.T) {
+	src, err := GenerateRangeTables("foo", []*RangeTable{
+		{
+			Name:   "Upper",
+			Source: "gc=Lu",
+			Ranges: []*property.CodePointRange{
+				property.NewCodePointRange(0x41, 0x5A),
+			},
+		},
+		{
+			Name:   "Emoticons",
+			Source: "blk=Emoticons",
+			Ranges: []*property.CodePointRange{
+				property.NewCodePointRange(0x1F600, 0x1F64F),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "tables.go", src, 0); err != nil {
+		t.Fatalf("the generated source is invalid: %v\n%s", err, src)
+	}
+	for _, s := range []string{
+		"package foo\n",
+		"var Upper = &unicode.RangeTable{",
+		"{0x0041, 0x005A, 1},",
+		"LatinOffset: 1,",
+		"{0x1F600, 0x1F64F, 1},",
+	} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("the generated source doesn't contain %q:\n%s", s, src)
+		}
+	}
+
+	for _, tables := range [][]*RangeTable{
+		{{Name: "1st"}},
+		{{Name: "A"}, {Name: "A"}},
+	} {
+		if _, err := GenerateRangeTables("foo", tables); err == nil {
+			t.Fatalf("an error must occur")
+		}
+	}
+	if _, err := GenerateRangeTables("foo-bar", nil); err == nil {
+		t.Fatalf("an error must occur")
+	}
+}
@@ -0,0 +1,271 @@
+package gogen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/nihei9/ucdx/ucd"
+)
+
+// Trie is a two-stage trie of a property to generate.
+type Trie struct {
+	// Property is the name of the property. It's written in the doc comments.
+	Property string
+
+	// Binary makes the accessor return whether the value is `Yes` instead of the value.
+	Binary bool
+
+	// Values holds the values of all the code points from U+0000 to U+10FFFF.
+	Values []string
+}
+
+// trieTables are the tables of a two-stage trie. A code point `c` has the value
+// `values[stage2[stage1[c>>shift]<<shift|c&(1<<shift-1)]]`.
+type trieTables struct {
+	shift  uint
+	stage1 []int
+	stage2 []int
+	values []string
+}
+
+// buildTrie builds the most compact two-stage trie of values among the block sizes from 16 to 256 code points.
+// ID 0 is the value of U+10FFFF, which is the default value of unassigned code points, and the other values have
+// IDs in order of their first appearances. A binary property has `No` as ID 0 and `Yes` as ID 1.
+func buildTrie(values []string, binary bool) (*trieTables, error) {
+	if len(values) != unicode.MaxRune+1 {
+		return nil, fmt.Errorf("a trie needs the values of all code points; got: %v", len(values))
+	}
+
+	var names []string
+	if binary {
+		names = []string{"No", "Yes"}
+	} else {
+		names = []string{values[unicode.MaxRune]}
+	}
+	idOf := map[string]int{}
+	for id, v := range names {
+		idOf[v] = id
+	}
+	ids := make([]int, len(values))
+	for c, v := range values {
+		id, ok := idOf[v]
+		if !ok {
+			if binary {
+				return nil, fmt.Errorf("a binary property has a value other than Yes and No: U+%04X: %v", c, v)
+			}
+			id = len(names)
+			names = append(names, v)
+			idOf[v] = id
+		}
+		ids[c] = id
+	}
+	if len(names) > 0x10000 {
+		return nil, fmt.Errorf("a trie can hold up to 65536 values; got: %v", len(names))
+	}
+
+	var best *trieTables
+	for shift := uint(4); shift <= 8; shift++ {
+		t := buildTrieTables(ids, shift)
+		t.values = names
+		if best == nil || t.size() < best.size() {
+			best = t
+		}
+	}
+	return best, nil
+}
+
+func buildTrieTables(ids []int, shift uint) *trieTables {
+	blockSize := 1 << shift
+	t := &trieTables{
+		shift:  shift,
+		stage1: make([]int, len(ids)/blockSize),
+	}
+	blocks := map[string]int{}
+	key := make([]byte, blockSize*2)
+	for i := range t.stage1 {
+		block := ids[i*blockSize : (i+1)*blockSize]
+		for j, id := range block {
+			key[j*2] = byte(id >> 8)
+			key[j*2+1] = byte(id)
+		}
+		n, ok := blocks[string(key)]
+		if !ok {
+			n = len(t.stage2) / blockSize
+			t.stage2 = append(t.stage2, block...)
+			blocks[string(key)] = n
+		}
+		t.stage1[i] = n
+	}
+	return t
+}
+
+// size returns the number of bytes the stages occupy.
+func (t *trieTables) size() int {
+	return len(t.stage1)*intTypeSize(len(t.stage2)>>t.shift-1) + len(t.stage2)*intTypeSize(len(t.values)-1)
+}
+
+func (t *trieTables) lookup(c rune) string {
+	return t.values[t.stage2[t.stage1[c>>t.shift]<<t.shift|int(c)&(1<<t.shift-1)]]
+}
+
+func intTypeSize(max int) int {
+	if max <= 0xFF {
+		return 1
+	}
+	return 2
+}
+
+func intTypeName(max int) string {
+	if max <= 0xFF {
+		return "uint8"
+	}
+	return "uint16"
+}
+
+// GenerateTrie returns gofmt'd Go source code of a package containing a two-stage trie with a `Lookup(r rune)`
+// accessor, and the source code of a test cross-checking every code point against the ranges of the values.
+func GenerateTrie(pkg string, trie *Trie) ([]byte, []byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, nil, fmt.Errorf("invalid package name: %v", pkg)
+	}
+	t, err := buildTrie(trie.Values, trie.Binary)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	src, err := generateTrieSource(pkg, trie, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	test, err := generateTrieTest(pkg, trie, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	return src, test, nil
+}
+
+func generateTrieSource(pkg string, trie *Trie, t *trieTables) ([]byte, error) {
+	idType := intTypeName(len(t.values) - 1)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by ucdx gen trie; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %v\n\n", pkg)
+	if trie.Binary {
+		fmt.Fprintf(&b, "// Lookup returns whether a code point has the %v property in Unicode %v.\n", trie.Property, ucd.UnicodeVersion)
+		fmt.Fprintf(&b, "func Lookup(r rune) bool {\n")
+		fmt.Fprintf(&b, "return lookupID(r) != 0\n")
+		fmt.Fprintf(&b, "}\n\n")
+	} else {
+		fmt.Fprintf(&b, "// Lookup returns the %v value of a code point in Unicode %v. A rune out of the range of code points has\n", trie.Property, ucd.UnicodeVersion)
+		fmt.Fprintf(&b, "// the value of U+10FFFF.\n")
+		fmt.Fprintf(&b, "func Lookup(r rune) string {\n")
+		fmt.Fprintf(&b, "return values[lookupID(r)]\n")
+		fmt.Fprintf(&b, "}\n\n")
+		fmt.Fprintf(&b, "// values are the %v values indexed by their IDs.\n", trie.Property)
+		fmt.Fprintf(&b, "var values = [...]string{\n")
+		for _, v := range t.values {
+			fmt.Fprintf(&b, "%q,\n", v)
+		}
+		fmt.Fprintf(&b, "}\n\n")
+	}
+	fmt.Fprintf(&b, "// blockShift is the number of bits of a code point indexing a block of stage2.\n")
+	fmt.Fprintf(&b, "const blockShift = %v\n\n", t.shift)
+	fmt.Fprintf(&b, "func lookupID(r rune) %v {\n", idType)
+	fmt.Fprintf(&b, "if r < 0 || r > 0x10FFFF {\n")
+	fmt.Fprintf(&b, "return 0\n")
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "return stage2[int(stage1[r>>blockShift])<<blockShift|int(r&(1<<blockShift-1))]\n")
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "// stage1 maps the upper bits of a code point to a block of stage2.\n")
+	writeIntArray(&b, "stage1", intTypeName(len(t.stage2)>>t.shift-1), t.stage1)
+	fmt.Fprintf(&b, "\n// stage2 maps the lower bits of a code point in a block to the ID of its value.\n")
+	writeIntArray(&b, "stage2", idType, t.stage2)
+
+	return format.Source([]byte(b.String()))
+}
+
+func writeIntArray(b *strings.Builder, name string, typ string, elems []int) {
+	fmt.Fprintf(b, "var %v = [...]%v{", name, typ)
+	for i, e := range elems {
+		if i%16 == 0 {
+			fmt.Fprintf(b, "\n")
+		} else {
+			fmt.Fprintf(b, " ")
+		}
+		fmt.Fprintf(b, "%v,", e)
+	}
+	fmt.Fprintf(b, "\n}\n")
+}
+
+func generateTrieTest(pkg string, trie *Trie, t *trieTables) ([]byte, error) {
+	// The ranges are made from the values directly so that the test doesn't depend on the trie.
+	ranges := map[string][][2]rune{}
+	for c := rune(0); c <= unicode.MaxRune; c++ {
+		v := trie.Values[c]
+		rs := ranges[v]
+		if c > 0 && trie.Values[c-1] == v {
+			rs[len(rs)-1][1] = c
+			continue
+		}
+		ranges[v] = append(rs, [2]rune{c, c})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by ucdx gen trie; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %v\n\n", pkg)
+	fmt.Fprintf(&b, "import \"testing\"\n\n")
+	fmt.Fprintf(&b, "// testRanges are the code point ranges of each %v value in Unicode %v.\n", trie.Property, ucd.UnicodeVersion)
+	fmt.Fprintf(&b, "var testRanges = map[string][][2]rune{\n")
+	for _, v := range t.values {
+		if len(ranges[v]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%q: {", v)
+		for i, r := range ranges[v] {
+			if i%8 == 0 {
+				fmt.Fprintf(&b, "\n")
+			} else {
+				fmt.Fprintf(&b, " ")
+			}
+			fmt.Fprintf(&b, "{0x%04X, 0x%04X},", r[0], r[1])
+		}
+		fmt.Fprintf(&b, "\n},\n")
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "func TestLookup(t *testing.T) {\n")
+	fmt.Fprintf(&b, "expected := make([]string, 0x110000)\n")
+	fmt.Fprintf(&b, "for v, rs := range testRanges {\n")
+	fmt.Fprintf(&b, "for _, r := range rs {\n")
+	fmt.Fprintf(&b, "for c := r[0]; c <= r[1]; c++ {\n")
+	fmt.Fprintf(&b, "expected[c] = v\n")
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "for c, v := range expected {\n")
+	if trie.Binary {
+		fmt.Fprintf(&b, "if actual := Lookup(rune(c)); actual != (v == \"Yes\") {\n")
+	} else {
+		fmt.Fprintf(&b, "if actual := Lookup(rune(c)); actual != v {\n")
+	}
+	fmt.Fprintf(&b, "t.Fatalf(\"unexpected value of U+%%04X: want: %%v, got: %%v\", c, v, actual)\n")
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "}\n")
+	// Runes out of the range of code points have the value of ID 0.
+	fmt.Fprintf(&b, "for _, c := range []rune{-1, 0x110000} {\n")
+	if trie.Binary {
+		fmt.Fprintf(&b, "if Lookup(c) {\n")
+		fmt.Fprintf(&b, "t.Fatalf(\"%%v must not have the property\", c)\n")
+	} else {
+		fmt.Fprintf(&b, "if actual := Lookup(c); actual != Lookup(0x10FFFF) {\n")
+		fmt.Fprintf(&b, "t.Fatalf(\"unexpected value of %%v: want: %%v, got: %%v\", c, Lookup(0x10FFFF), actual)\n")
+	}
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "}\n")
+
+	return format.Source([]byte(b.String()))
+}
@@ -0,0 +1,109 @@
+package gogen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func makeTestValues() []string {
+	values := make([]string, unicode.MaxRune+1)
+	for c := range values {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			values[c] = "lu"
+		case c >= 'a' && c <= 'z':
+			values[c] = "ll"
+		case c >= 0x10400 && c <= 0x10427:
+			values[c] = "lu"
+		default:
+			values[c] = "cn"
+		}
+	}
+	return values
+}
+
+func TestBuildTrie(t *testing.T) {
+	values := makeTestValues()
+	trie, err := buildTrie(values, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trie.values[0] != "cn" {
+		t.Fatalf("ID 0 must be the value of U+10FFFF: got: %v", trie.values[0])
+	}
+	for c, v := range values {
+		if actual := trie.lookup(rune(c)); actual != v {
+			t.Fatalf("unexpected value of U+%04X: want: %v, got: %v", c, v, actual)
+		}
+	}
+
+	if _, err := buildTrie(values, true); err == nil {
+		t.Fatalf("an error must occur")
+	}
+	if _, err := buildTrie(values[:0x10000], false); err == nil {
+		t.Fatalf("an error must occur")
+	}
+}
+
+func TestGenerateTrie(t *testing.T) {
+	values := makeTestValues()
+	binValues := make([]string, len(values))
+	for c, v := range values {
+		binValues[c] = "No"
+		if v == "lu" {
+			binValues[c] = "Yes"
+		}
+	}
+
+	tests := []struct {
+		trie     *Trie
+		expected []string
+	}{
+		{
+			trie: &Trie{
+				Property: "General_Category",
+				Values:   values,
+			},
+			expected: []string{
+				"func Lookup(r rune) string {",
+				`"cn",`,
+				"var stage2 = [...]uint8{",
+			},
+		},
+		{
+			trie: &Trie{
+				Property: "Uppercase",
+				Binary:   true,
+				Values:   binValues,
+			},
+			expected: []string{
+				"func Lookup(r rune) bool {",
+				"var stage2 = [...]uint8{",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.trie.Property, func(t *testing.T) {
+			src, test, err := GenerateTrie("foo", tt.trie)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range [][]byte{src, test} {
+				if _, err := parser.ParseFile(token.NewFileSet(), "lookup.go", s, 0); err != nil {
+					t.Fatalf("the generated source is invalid: %v\n%s", err, s)
+				}
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(string(src), s) {
+					t.Errorf("the generated source doesn't contain %q", s)
+				}
+			}
+			if !strings.Contains(string(test), "{0x10400, 0x10427},") {
+				t.Errorf("the generated test doesn't contain the ranges")
+			}
+		})
+	}
+}
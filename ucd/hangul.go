@@ -0,0 +1,35 @@
+package ucd
+
+// See section 3.12 Conjoining Jamo Behavior in [Unicode].
+const (
+	hangulSBase  = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+func isHangulSyllable(c rune) bool {
+	return c >= hangulSBase && c < hangulSBase+hangulSCount
+}
+
+// decomposeHangulSyllable returns the canonical decomposition mapping of a Hangul syllable. An LV syllable is
+// decomposed into <L, V>, and an LVT syllable is decomposed into <LV, T>.
+//
+// See section 3.12 Conjoining Jamo Behavior in [Unicode].
+func decomposeHangulSyllable(s rune) []rune {
+	sIndex := s - hangulSBase
+	tIndex := sIndex % hangulTCount
+	if tIndex == 0 {
+		l := hangulLBase + sIndex/hangulNCount
+		v := hangulVBase + (sIndex%hangulNCount)/hangulTCount
+		return []rune{l, v}
+	}
+	lv := hangulSBase + (sIndex/hangulTCount)*hangulTCount
+	t := hangulTBase + tIndex
+	return []rune{lv, t}
+}
@@ -0,0 +1,211 @@
+package ucd
+
+import (
+	"sort"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// rangeIndex is an index of disjoint code point ranges sorted in ascending order. A range has a value, and lookup
+// finds the range containing a code point by binary search.
+type rangeIndex struct {
+	from   []rune
+	to     []rune
+	values []string
+}
+
+type rangeIndexEntry struct {
+	cp    *property.CodePointRange
+	value string
+}
+
+func newRangeIndex(entries []*rangeIndexEntry) *rangeIndex {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].cp[0] < entries[j].cp[0]
+	})
+	idx := &rangeIndex{
+		from:   make([]rune, len(entries)),
+		to:     make([]rune, len(entries)),
+		values: make([]string, len(entries)),
+	}
+	for i, e := range entries {
+		idx.from[i], idx.to[i] = e.cp.Range()
+		idx.values[i] = e.value
+	}
+	return idx
+}
+
+// newSymbolRangeIndex returns an index of the ranges of property values.
+func newSymbolRangeIndex(entries map[property.PropertyValueSymbol][]*property.CodePointRange) *rangeIndex {
+	var es []*rangeIndexEntry
+	for v, cps := range entries {
+		for _, cp := range cps {
+			es = append(es, &rangeIndexEntry{
+				cp:    cp,
+				value: v.String(),
+			})
+		}
+	}
+	return newRangeIndex(es)
+}
+
+// newBinaryRangeIndex returns an index of the ranges of a binary property. The ranges have no values.
+func newBinaryRangeIndex(cps []*property.CodePointRange) *rangeIndex {
+	es := make([]*rangeIndexEntry, len(cps))
+	for i, cp := range cps {
+		es[i] = &rangeIndexEntry{
+			cp: cp,
+		}
+	}
+	return newRangeIndex(es)
+}
+
+// lookup returns the position of the range containing a code point, or -1 when no range contains it.
+func (idx *rangeIndex) lookup(c rune) int {
+	i := sort.Search(len(idx.from), func(i int) bool {
+		return idx.from[i] > c
+	}) - 1
+	if i < 0 || c > idx.to[i] {
+		return -1
+	}
+	return i
+}
+
+// lookupValue returns the value of the range containing a code point.
+func (idx *rangeIndex) lookupValue(c rune) (string, bool) {
+	i := idx.lookup(c)
+	if i < 0 {
+		return "", false
+	}
+	return idx.values[i], true
+}
+
+// contain returns whether a range contains a code point.
+func (idx *rangeIndex) contain(c rune) bool {
+	return idx.lookup(c) >= 0
+}
+
+// ucdIndex holds the indexes UCD looks up properties with.
+type ucdIndex struct {
+	// names holds the names of the code points UnicodeData.txt lists one by one, and nameRanges holds the names of
+	// the ranges it lists.
+	names      map[rune]property.PropertyName
+	nameRanges *rangeIndex
+
+	nameAliases   map[rune]*property.NameAliasesEntry
+	specialCasing map[rune]*property.SpecialCasingEntry
+
+	generalCategory         *rangeIndex
+	canonicalCombiningClass *rangeIndex
+	bidiClass               *rangeIndex
+	bidiMirrored            *rangeIndex
+	script                  *rangeIndex
+	block                   *rangeIndex
+	lineBreak               *rangeIndex
+	eastAsianWidth          *rangeIndex
+	indicConjunctBreak      *rangeIndex
+	graphemeClusterBreak    *rangeIndex
+	wordBreak               *rangeIndex
+	sentenceBreak           *rangeIndex
+
+	// scriptExtensions holds the entries of ScriptExtensions.txt sorted by their code points.
+	scriptExtensions []*property.ScriptExtensionsEntry
+
+	// binary holds the indexes of the binary properties of DerivedCoreProperties.txt, PropList.txt, and the others.
+	binary map[property.PropertyName]*rangeIndex
+}
+
+// BuildIndex builds the indexes looking up properties in logarithmic time. The lookups build them on the first call
+// when they aren't built yet, so call BuildIndex before sharing the UCD between goroutines.
+func (u *UCD) BuildIndex() {
+	idx := &ucdIndex{
+		names:         map[rune]property.PropertyName{},
+		nameAliases:   map[rune]*property.NameAliasesEntry{},
+		specialCasing: map[rune]*property.SpecialCasingEntry{},
+		binary:        map[property.PropertyName]*rangeIndex{},
+	}
+
+	var nameRanges []*rangeIndexEntry
+	for na, cp := range u.UnicodeData.Name {
+		from, to := cp.Range()
+		if from == to {
+			idx.names[from] = na
+			continue
+		}
+		nameRanges = append(nameRanges, &rangeIndexEntry{
+			cp:    cp,
+			value: na.String(),
+		})
+	}
+	idx.nameRanges = newRangeIndex(nameRanges)
+	for _, e := range u.NameAliases.Entries {
+		if _, ok := idx.nameAliases[e.CP]; !ok {
+			idx.nameAliases[e.CP] = e
+		}
+	}
+	for _, e := range u.SpecialCasing.Entries {
+		if len(e.Conditions) > 0 {
+			continue
+		}
+		if _, ok := idx.specialCasing[e.CP]; !ok {
+			idx.specialCasing[e.CP] = e
+		}
+	}
+
+	idx.generalCategory = newSymbolRangeIndex(u.UnicodeData.GeneralCategory)
+	idx.canonicalCombiningClass = newSymbolRangeIndex(u.UnicodeData.CanonicalCombiningClass)
+	idx.bidiClass = newSymbolRangeIndex(u.UnicodeData.BidiClass)
+	idx.bidiMirrored = newBinaryRangeIndex(u.UnicodeData.BidiMirrored)
+	idx.script = newSymbolRangeIndex(u.Scripts.Entries)
+	idx.lineBreak = newSymbolRangeIndex(u.LineBreak.Entries)
+	idx.eastAsianWidth = newSymbolRangeIndex(u.EastAsianWidth.Entries)
+	idx.indicConjunctBreak = newSymbolRangeIndex(u.DerivedCoreProperties.IndicConjunctBreak)
+	idx.graphemeClusterBreak = newSymbolRangeIndex(u.GraphemeBreakProperty.Entries)
+	idx.wordBreak = newSymbolRangeIndex(u.WordBreakProperty.Entries)
+	idx.sentenceBreak = newSymbolRangeIndex(u.SentenceBreakProperty.Entries)
+
+	{
+		es := make([]*rangeIndexEntry, len(u.Blocks.Entries))
+		for i, e := range u.Blocks.Entries {
+			es[i] = &rangeIndexEntry{
+				cp:    e.CP,
+				value: e.Block.String(),
+			}
+		}
+		idx.block = newRangeIndex(es)
+	}
+	idx.scriptExtensions = make([]*property.ScriptExtensionsEntry, len(u.ScriptExtensions.Entries))
+	copy(idx.scriptExtensions, u.ScriptExtensions.Entries)
+	sort.Slice(idx.scriptExtensions, func(i, j int) bool {
+		return idx.scriptExtensions[i].CP[0] < idx.scriptExtensions[j].CP[0]
+	})
+
+	for name, cps := range u.DerivedCoreProperties.Entries {
+		idx.binary[name] = newBinaryRangeIndex(cps)
+	}
+	for name, cps := range u.PropList.Entries {
+		idx.binary[name] = newBinaryRangeIndex(cps)
+	}
+	idx.binary[property.PropNameExtendedPictographic] = newBinaryRangeIndex(u.EmojiData.Entries[property.PropNameExtendedPictographic])
+	idx.binary[property.PropNameFullCompositionExclusion] = newBinaryRangeIndex(u.DerivedNormalizationProps.Entries[property.PropNameFullCompositionExclusion])
+
+	u.idx = idx
+}
+
+// lookupScriptExtensions returns the entry of ScriptExtensions.txt containing a code point.
+func (idx *ucdIndex) lookupScriptExtensions(c rune) *property.ScriptExtensionsEntry {
+	i := sort.Search(len(idx.scriptExtensions), func(i int) bool {
+		return idx.scriptExtensions[i].CP[0] > c
+	}) - 1
+	if i < 0 || !idx.scriptExtensions[i].CP.Contain(c) {
+		return nil
+	}
+	return idx.scriptExtensions[i]
+}
+
+func (u *UCD) index() *ucdIndex {
+	if u.idx == nil {
+		u.BuildIndex()
+	}
+	return u.idx
+}
@@ -0,0 +1,50 @@
+package ucd
+
+import (
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+func TestRangeIndex(t *testing.T) {
+	idx := newSymbolRangeIndex(map[property.PropertyValueSymbol][]*property.CodePointRange{
+		"lu": {
+			property.NewCodePointRange(0x41, 0x5A),
+			property.NewCodePointRange(0xC0, 0xD6),
+		},
+		"ll": {
+			property.NewCodePointRange(0x61, 0x7A),
+		},
+		"nd": {
+			property.NewCodePointRange(0x30, 0x39),
+		},
+	})
+
+	tests := []struct {
+		c        rune
+		expected string
+	}{
+		{c: 0x00},
+		{c: 0x30, expected: "nd"},
+		{c: 0x39, expected: "nd"},
+		{c: 0x40},
+		{c: 0x41, expected: "lu"},
+		{c: 0x5A, expected: "lu"},
+		{c: 0x60},
+		{c: 0x7A, expected: "ll"},
+		{c: 0xC5, expected: "lu"},
+		{c: 0xD7},
+		{c: 0x10FFFF},
+	}
+	for _, tt := range tests {
+		v, ok := idx.lookupValue(tt.c)
+		if ok != (tt.expected != "") || v != tt.expected {
+			t.Errorf("unexpected value of U+%04X: want: %q, got: %q (%v)", tt.c, tt.expected, v, ok)
+		}
+	}
+
+	empty := newBinaryRangeIndex(nil)
+	if empty.contain('a') {
+		t.Errorf("an empty index must contain no code points")
+	}
+}
@@ -0,0 +1,53 @@
+package normalize
+
+// See section 3.12 Conjoining Jamo Behavior in [Unicode].
+const (
+	hangulSBase  = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+func isHangulSyllable(c rune) bool {
+	return c >= hangulSBase && c < hangulSBase+hangulSCount
+}
+
+// decomposeHangulSyllable returns the full canonical decomposition of a Hangul syllable.
+func decomposeHangulSyllable(s rune) []rune {
+	sIndex := s - hangulSBase
+	l := hangulLBase + sIndex/hangulNCount
+	v := hangulVBase + (sIndex%hangulNCount)/hangulTCount
+	t := hangulTBase + sIndex%hangulTCount
+	if t == hangulTBase {
+		return []rune{l, v}
+	}
+	return []rune{l, v, t}
+}
+
+// composeHangul returns a Hangul syllable composed of two characters. The first character must be a leading
+// consonant or an LV syllable, and the second must be a vowel or a trailing consonant respectively.
+func composeHangul(first, second rune) (rune, bool) {
+	lIndex := first - hangulLBase
+	if lIndex >= 0 && lIndex < hangulLCount {
+		vIndex := second - hangulVBase
+		if vIndex >= 0 && vIndex < hangulVCount {
+			return hangulSBase + (lIndex*hangulVCount+vIndex)*hangulTCount, true
+		}
+		return 0, false
+	}
+
+	sIndex := first - hangulSBase
+	if sIndex >= 0 && sIndex < hangulSCount && sIndex%hangulTCount == 0 {
+		// The index of a trailing consonant starts at 1 because 0 means an LV syllable.
+		tIndex := second - hangulTBase
+		if tIndex > 0 && tIndex < hangulTCount {
+			return first + tIndex, true
+		}
+	}
+	return 0, false
+}
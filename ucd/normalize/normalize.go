@@ -0,0 +1,237 @@
+// Package normalize implements the Unicode Normalization Forms using the data the UCD provides.
+//
+// See [UAX15] and section 3.11 Normalization Forms in [Unicode] for more details on the algorithm.
+package normalize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+type Form int
+
+const (
+	NFC Form = iota
+	NFD
+	NFKC
+	NFKD
+)
+
+var formNames = map[Form]string{
+	NFC:  "NFC",
+	NFD:  "NFD",
+	NFKC: "NFKC",
+	NFKD: "NFKD",
+}
+
+func (f Form) String() string {
+	if name, ok := formNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Form(%d)", int(f))
+}
+
+func (f Form) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// ParseForm returns a normalization form corresponding to a name such as `nfc`. The name is case-insensitive.
+func ParseForm(name string) (Form, error) {
+	for f, n := range formNames {
+		if strings.EqualFold(name, n) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown normalization form: %v", name)
+}
+
+func (f Form) compatibility() bool {
+	return f == NFKC || f == NFKD
+}
+
+func (f Form) composition() bool {
+	return f == NFC || f == NFKC
+}
+
+// Normalizer converts strings into the normalization forms.
+type Normalizer struct {
+	ccc           map[rune]uint8
+	canonical     map[rune][]rune
+	compatibility map[rune][]rune
+	composition   map[[2]rune]rune
+}
+
+// NewNormalizer builds a normalizer from the Canonical_Combining_Class and the decomposition properties defined in
+// UnicodeData.txt and the Full_Composition_Exclusion property defined in DerivedNormalizationProps.txt.
+func NewNormalizer(ud *property.UnicodeData, dnp *property.DerivedNormalizationProps) (*Normalizer, error) {
+	ccc := map[rune]uint8{}
+	for v, cps := range ud.CanonicalCombiningClass {
+		n, err := strconv.ParseUint(v.String(), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Canonical_Combining_Class: %v", v)
+		}
+		if n == 0 {
+			continue
+		}
+		for _, cp := range cps {
+			from, to := cp.Range()
+			for c := from; c <= to; c++ {
+				ccc[c] = uint8(n)
+			}
+		}
+	}
+
+	excluded := map[rune]bool{}
+	for _, cp := range dnp.Entries[property.PropNameFullCompositionExclusion] {
+		from, to := cp.Range()
+		for c := from; c <= to; c++ {
+			excluded[c] = true
+		}
+	}
+
+	n := &Normalizer{
+		ccc:           ccc,
+		canonical:     map[rune][]rune{},
+		compatibility: map[rune][]rune{},
+		composition:   map[[2]rune]rune{},
+	}
+	for c, d := range ud.Decomposition {
+		if d.Type == "canonical" {
+			n.canonical[c] = fullDecomposition(ud, c, false)
+
+			// A primary composite is a character that has a canonical decomposition mapping and is not excluded
+			// from composition. Full_Composition_Exclusion covers singletons and non-starter decompositions, so
+			// the remaining mappings always consist of two characters.
+			if !excluded[c] && len(d.Mapping) == 2 {
+				n.composition[[2]rune{d.Mapping[0], d.Mapping[1]}] = c
+			}
+		}
+		n.compatibility[c] = fullDecomposition(ud, c, true)
+	}
+
+	return n, nil
+}
+
+// fullDecomposition returns the full decomposition of a code point by applying the decomposition mappings
+// recursively.
+func fullDecomposition(ud *property.UnicodeData, c rune, compat bool) []rune {
+	d, ok := ud.Decomposition[c]
+	if !ok || (d.Type != "canonical" && !compat) {
+		return []rune{c}
+	}
+	var cs []rune
+	for _, m := range d.Mapping {
+		cs = append(cs, fullDecomposition(ud, m, compat)...)
+	}
+	return cs
+}
+
+// CombiningClass returns the Canonical_Combining_Class of a code point as a number.
+func (n *Normalizer) CombiningClass(c rune) uint8 {
+	return n.ccc[c]
+}
+
+// String returns a string converted into a normalization form.
+func (n *Normalizer) String(f Form, s string) string {
+	return string(n.Runes(f, []rune(s)))
+}
+
+// Runes returns a sequence of code points converted into a normalization form. The input remains unchanged.
+func (n *Normalizer) Runes(f Form, s []rune) []rune {
+	cs := n.decompose(s, f.compatibility())
+	if f.composition() {
+		cs = n.compose(cs)
+	}
+	return cs
+}
+
+// decompose returns the canonical or compatibility decomposition of a sequence of code points.
+//
+// See D68 and D65 in section 3.11 Normalization Forms in [Unicode].
+func (n *Normalizer) decompose(s []rune, compat bool) []rune {
+	decomp := n.canonical
+	if compat {
+		decomp = n.compatibility
+	}
+
+	cs := make([]rune, 0, len(s))
+	for _, c := range s {
+		if isHangulSyllable(c) {
+			cs = append(cs, decomposeHangulSyllable(c)...)
+			continue
+		}
+		if d, ok := decomp[c]; ok {
+			cs = append(cs, d...)
+			continue
+		}
+		cs = append(cs, c)
+	}
+	n.reorder(cs)
+	return cs
+}
+
+// reorder applies the Canonical Ordering Algorithm. It sorts each sequence of non-starters by their
+// Canonical_Combining_Class while keeping the order of characters having the same class.
+//
+// See D109 in section 3.11 Normalization Forms in [Unicode].
+func (n *Normalizer) reorder(cs []rune) {
+	for i := 1; i < len(cs); i++ {
+		cc := n.ccc[cs[i]]
+		if cc == 0 {
+			continue
+		}
+		for j := i; j > 0 && n.ccc[cs[j-1]] > cc; j-- {
+			cs[j-1], cs[j] = cs[j], cs[j-1]
+		}
+	}
+}
+
+// compose applies the Canonical Composition Algorithm to a decomposed sequence of code points. It modifies and
+// returns the input.
+//
+// See D117 in section 3.11 Normalization Forms in [Unicode].
+func (n *Normalizer) compose(cs []rune) []rune {
+	if len(cs) == 0 {
+		return cs
+	}
+
+	starterPos := 0
+	starter := cs[0]
+	// When a sequence begins with a non-starter, the following characters cannot be combined with it.
+	lastCC := int(n.ccc[starter])
+	if lastCC != 0 {
+		lastCC = 256
+	}
+	compPos := 1
+	for _, c := range cs[1:] {
+		cc := int(n.ccc[c])
+		// A character is blocked from the last starter when there is another character having the same or a
+		// higher combining class between them. `lastCC == 0` means the character is adjacent to the starter.
+		if lastCC < cc || lastCC == 0 {
+			if composite, ok := n.composePair(starter, c); ok {
+				cs[starterPos] = composite
+				starter = composite
+				continue
+			}
+		}
+		if cc == 0 {
+			starterPos = compPos
+			starter = c
+		}
+		lastCC = cc
+		cs[compPos] = c
+		compPos++
+	}
+	return cs[:compPos]
+}
+
+func (n *Normalizer) composePair(first, second rune) (rune, bool) {
+	if c, ok := composeHangul(first, second); ok {
+		return c, true
+	}
+	c, ok := n.composition[[2]rune{first, second}]
+	return c, ok
+}
@@ -0,0 +1,133 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+const testUnicodeData = `
+0041;LATIN CAPITAL LETTER A;Lu;0;L;;;;;N;;;;0061;
+0044;LATIN CAPITAL LETTER D;Lu;0;L;;;;;N;;;;0064;
+0061;LATIN SMALL LETTER A;Ll;0;L;;;;;N;;;0041;;0041
+0064;LATIN SMALL LETTER D;Ll;0;L;;;;;N;;;0044;;0044
+00C5;LATIN CAPITAL LETTER A WITH RING ABOVE;Lu;0;L;0041 030A;;;;N;LATIN CAPITAL LETTER A RING;;;00E5;
+0300;COMBINING GRAVE ACCENT;Mn;230;NSM;;;;;N;NON-SPACING GRAVE;;;;
+0307;COMBINING DOT ABOVE;Mn;230;NSM;;;;;N;NON-SPACING DOT ABOVE;;;;
+030A;COMBINING RING ABOVE;Mn;230;NSM;;;;;N;NON-SPACING RING ABOVE;;;;
+0323;COMBINING DOT BELOW;Mn;220;NSM;;;;;N;NON-SPACING DOT BELOW;;;;
+0340;COMBINING GRAVE TONE MARK;Mn;230;NSM;0300;;;;N;NON-SPACING GRAVE TONE MARK;;;;
+1E0A;LATIN CAPITAL LETTER D WITH DOT ABOVE;Lu;0;L;0044 0307;;;;N;;;;1E0B;
+1E0C;LATIN CAPITAL LETTER D WITH DOT BELOW;Lu;0;L;0044 0323;;;;N;;;;1E0D;
+212B;ANGSTROM SIGN;Lu;0;L;00C5;;;;N;ANGSTROM UNIT;;;00E5;
+FB01;LATIN SMALL LIGATURE FI;Ll;0;L;<compat> 0066 0069;;;;N;;;;;
+AC00;<Hangul Syllable, First>;Lo;0;L;;;;;N;;;;;
+D7A3;<Hangul Syllable, Last>;Lo;0;L;;;;;N;;;;;
+`
+
+const testDerivedNormalizationProps = `
+0340..0341    ; Full_Composition_Exclusion # Mn   [2] COMBINING GRAVE TONE MARK..COMBINING ACUTE TONE MARK
+212B          ; Full_Composition_Exclusion # L&       ANGSTROM SIGN
+`
+
+func TestNormalizer(t *testing.T) {
+	ud, err := parser.ParseUnicodeData(strings.NewReader(testUnicodeData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dnp, err := parser.ParseDerivedNormalizationProps(strings.NewReader(testDerivedNormalizationProps))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := NewNormalizer(ud, dnp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		src  []rune
+		nfd  []rune
+		nfc  []rune
+		nfkd []rune
+		nfkc []rune
+	}{
+		// A singleton decomposition is excluded from composition.
+		{
+			src:  []rune{0x212B},
+			nfd:  []rune{0x0041, 0x030A},
+			nfc:  []rune{0x00C5},
+			nfkd: []rune{0x0041, 0x030A},
+			nfkc: []rune{0x00C5},
+		},
+		// Marks are reordered by their combining classes.
+		{
+			src:  []rune{0x1E0A, 0x0323},
+			nfd:  []rune{0x0044, 0x0323, 0x0307},
+			nfc:  []rune{0x1E0C, 0x0307},
+			nfkd: []rune{0x0044, 0x0323, 0x0307},
+			nfkc: []rune{0x1E0C, 0x0307},
+		},
+		// A mark having the same combining class blocks composition.
+		{
+			src:  []rune{0x0041, 0x0300, 0x030A},
+			nfd:  []rune{0x0041, 0x0300, 0x030A},
+			nfc:  []rune{0x0041, 0x0300, 0x030A},
+			nfkd: []rune{0x0041, 0x0300, 0x030A},
+			nfkc: []rune{0x0041, 0x0300, 0x030A},
+		},
+		{
+			src:  []rune{0x0340},
+			nfd:  []rune{0x0300},
+			nfc:  []rune{0x0300},
+			nfkd: []rune{0x0300},
+			nfkc: []rune{0x0300},
+		},
+		{
+			src:  []rune{0xFB01},
+			nfd:  []rune{0xFB01},
+			nfc:  []rune{0xFB01},
+			nfkd: []rune{0x0066, 0x0069},
+			nfkc: []rune{0x0066, 0x0069},
+		},
+		// Hangul syllables are decomposed and composed algorithmically.
+		{
+			src:  []rune{0xAC01},
+			nfd:  []rune{0x1100, 0x1161, 0x11A8},
+			nfc:  []rune{0xAC01},
+			nfkd: []rune{0x1100, 0x1161, 0x11A8},
+			nfkc: []rune{0xAC01},
+		},
+		{
+			src:  []rune{0x1100, 0x1161, 0x11A8},
+			nfd:  []rune{0x1100, 0x1161, 0x11A8},
+			nfc:  []rune{0xAC01},
+			nfkd: []rune{0x1100, 0x1161, 0x11A8},
+			nfkc: []rune{0xAC01},
+		},
+		// A sequence beginning with a non-starter.
+		{
+			src:  []rune{0x030A, 0x0041},
+			nfd:  []rune{0x030A, 0x0041},
+			nfc:  []rune{0x030A, 0x0041},
+			nfkd: []rune{0x030A, 0x0041},
+			nfkc: []rune{0x030A, 0x0041},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			for f, expected := range map[Form][]rune{
+				NFD:  tt.nfd,
+				NFC:  tt.nfc,
+				NFKD: tt.nfkd,
+				NFKC: tt.nfkc,
+			} {
+				actual := n.Runes(f, tt.src)
+				if fmt.Sprintf("%X", actual) != fmt.Sprintf("%X", expected) {
+					t.Errorf("unexpected %v: want: %X, got: %X", f, expected, actual)
+				}
+			}
+		})
+	}
+}
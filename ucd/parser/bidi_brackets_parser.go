@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseBidiBrackets parses the BidiBrackets.txt.
+func ParseBidiBrackets(r io.Reader) (*property.BidiBrackets, error) {
+	brackets := &property.BidiBrackets{
+		PairedBracket:     map[rune]rune{},
+		PairedBracketType: map[rune]property.PropertyValueSymbol{},
+	}
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		c, err := decodeHexToRune(p.fields[0].String())
+		if err != nil {
+			return nil, err
+		}
+		pair, err := decodeHexToRune(p.fields[1].String())
+		if err != nil {
+			return nil, err
+		}
+		brackets.PairedBracket[c] = pair
+		brackets.PairedBracketType[c] = p.fields[2].normalizedSymbol()
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return brackets, nil
+}
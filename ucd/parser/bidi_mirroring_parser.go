@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseBidiMirroring parses the BidiMirroring.txt.
+func ParseBidiMirroring(r io.Reader) (*property.BidiMirroring, error) {
+	mirroring := &property.BidiMirroring{
+		MirroringGlyph: map[rune]rune{},
+	}
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		c, err := decodeHexToRune(p.fields[0].String())
+		if err != nil {
+			return nil, err
+		}
+		glyph, err := decodeHexToRune(p.fields[1].String())
+		if err != nil {
+			return nil, err
+		}
+		mirroring.MirroringGlyph[c] = glyph
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return mirroring, nil
+}
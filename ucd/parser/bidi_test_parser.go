@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Paragraph directions of the test data files for the Unicode Bidirectional Algorithm. The values follow the second
+// field of BidiCharacterTest.txt.
+const (
+	BidiDirectionLTR  = 0
+	BidiDirectionRTL  = 1
+	BidiDirectionAuto = 2
+)
+
+// BidiTestCase is a test case of BidiTest.txt.
+//
+// `Classes` holds the Bidi_Class values of the input, and `Directions` holds the paragraph directions the test case
+// applies to. `Levels` holds the resolved embedding levels of the input; -1 means the character is removed by rule
+// X9. `Reorder` holds the indexes of the characters in visual order, excluding the removed characters.
+type BidiTestCase struct {
+	Line       int
+	Classes    []property.PropertyValueSymbol
+	Directions []int
+	Levels     []int
+	Reorder    []int
+}
+
+// ParseBidiTest parses BidiTest.txt. The file has the following format:
+//
+//	@Levels:	x 0
+//	@Reorder:	1
+//	LRE L; 7
+//
+// The `@Levels` and `@Reorder` lines specify the expected results of the records following them. Each record
+// consists of a sequence of Bidi_Class values and a bitset of paragraph directions; 1 means auto, 2 means LTR, and
+// 4 means RTL.
+func ParseBidiTest(r io.Reader) ([]*BidiTestCase, error) {
+	var cases []*BidiTestCase
+	var levels []int
+	var reorder []int
+	line := 0
+	p := newParser(r)
+	for p.scanner.Scan() {
+		line++
+		p.parseRecord(p.scanner.Text())
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		var err error
+		switch s := p.fields[0].String(); {
+		case strings.HasPrefix(s, "@Levels:"):
+			levels, err = parseBidiLevels(strings.TrimPrefix(s, "@Levels:"))
+		case strings.HasPrefix(s, "@Reorder:"):
+			reorder, err = parseBidiIndexes(strings.TrimPrefix(s, "@Reorder:"))
+		case strings.HasPrefix(s, "@"):
+			// Ignore unknown directives.
+		default:
+			if len(p.fields) < 2 {
+				return nil, fmt.Errorf("a record must have 2 fields: line %v", line)
+			}
+			var bits int
+			bits, err = strconv.Atoi(p.fields[1].String())
+			if err != nil {
+				break
+			}
+			c := &BidiTestCase{
+				Line:    line,
+				Levels:  levels,
+				Reorder: reorder,
+			}
+			for _, cls := range strings.Fields(s) {
+				c.Classes = append(c.Classes, field(cls).normalizedSymbol())
+			}
+			if bits&1 != 0 {
+				c.Directions = append(c.Directions, BidiDirectionAuto)
+			}
+			if bits&2 != 0 {
+				c.Directions = append(c.Directions, BidiDirectionLTR)
+			}
+			if bits&4 != 0 {
+				c.Directions = append(c.Directions, BidiDirectionRTL)
+			}
+			cases = append(cases, c)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: line %v", err, line)
+		}
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
+
+// BidiCharacterTestCase is a test case of BidiCharacterTest.txt.
+//
+// `Direction` is the paragraph direction the input is resolved in, and `ParagraphLevel` is the resolved paragraph
+// embedding level. `Levels` and `Reorder` have the same meaning as the ones of BidiTestCase.
+type BidiCharacterTestCase struct {
+	Line           int
+	Runes          []rune
+	Direction      int
+	ParagraphLevel int
+	Levels         []int
+	Reorder        []int
+}
+
+// ParseBidiCharacterTest parses BidiCharacterTest.txt. Each record of the file has the following fields:
+//
+//	05D0 0028 0061 0029;0;0;1 0 0 0;0 1 2 3
+//
+// They are code points, a paragraph direction (0 means LTR, 1 means RTL, and 2 means auto), a resolved paragraph
+// embedding level, resolved embedding levels, and indexes of the characters in visual order.
+func ParseBidiCharacterTest(r io.Reader) ([]*BidiCharacterTestCase, error) {
+	var cases []*BidiCharacterTestCase
+	line := 0
+	p := newParser(r)
+	for p.scanner.Scan() {
+		line++
+		p.parseRecord(p.scanner.Text())
+		if len(p.fields) == 0 {
+			continue
+		}
+		if len(p.fields) < 5 {
+			return nil, fmt.Errorf("a record must have 5 fields: line %v", line)
+		}
+
+		c := &BidiCharacterTestCase{
+			Line: line,
+		}
+		var err error
+		c.Runes, err = p.fields[0].codePointSequence()
+		if err == nil {
+			c.Direction, err = strconv.Atoi(p.fields[1].String())
+		}
+		if err == nil {
+			c.ParagraphLevel, err = strconv.Atoi(p.fields[2].String())
+		}
+		if err == nil {
+			c.Levels, err = parseBidiLevels(p.fields[3].String())
+		}
+		if err == nil {
+			c.Reorder, err = parseBidiIndexes(p.fields[4].String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: line %v", err, line)
+		}
+		cases = append(cases, c)
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
+
+// parseBidiLevels parses a sequence of embedding levels delimited by spaces. `x` results in -1.
+func parseBidiLevels(s string) ([]int, error) {
+	var levels []int
+	for _, tok := range strings.Fields(s) {
+		if tok == "x" {
+			levels = append(levels, -1)
+			continue
+		}
+		l, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, l)
+	}
+	return levels, nil
+}
+
+func parseBidiIndexes(s string) ([]int, error) {
+	var indexes []int
+	for _, tok := range strings.Fields(s) {
+		i, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes, nil
+}
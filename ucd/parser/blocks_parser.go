@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseBlocks parses the Blocks.txt.
+func ParseBlocks(r io.Reader) (*property.Blocks, error) {
+	var entries []*property.BlocksEntry
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		cp, err := p.fields[0].codePointRange()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &property.BlocksEntry{
+			CP:    cp,
+			Block: p.fields[1].normalizedSymbol(),
+		})
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &property.Blocks{
+		Entries: entries,
+	}, nil
+}
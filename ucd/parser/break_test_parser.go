@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var reBreakTestRule = regexp.MustCompile(`[÷×]\s*\[([0-9.]+)\]`)
+
+// BreakTestCase is a test case of the test data files for text segmentation, such as GraphemeBreakTest.txt and
+// LineBreakTest.txt.
+//
+// `Breaks` holds decisions at all positions of `Runes`; `Breaks[i]` is true when there is a boundary just before
+// `Runes[i]`, so its length is len(Runes)+1. `Rules` holds the numbers of the rules that determine each decision,
+// such as `9.1`. The test data files show the rules in their comments. When a record has no such comment, `Rules`
+// is nil.
+type BreakTestCase struct {
+	Line   int
+	Runes  []rune
+	Breaks []bool
+	Rules  []string
+}
+
+// ParseBreakTest parses a test data file for text segmentation. These files share the following format:
+//
+//	÷ 0020 × 0308 ÷	#  ÷ [0.2] SPACE (Other) × [9.0] COMBINING DIAERESIS (Extend_ExtCccZwj) ÷ [0.3]
+//
+// `÷` means there is a boundary at the position, and `×` means there is not.
+func ParseBreakTest(r io.Reader) ([]*BreakTestCase, error) {
+	var cases []*BreakTestCase
+	line := 0
+	p := newParser(r)
+	for p.scanner.Scan() {
+		line++
+		p.parseRecord(p.scanner.Text())
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		c := &BreakTestCase{
+			Line: line,
+		}
+		for i, tok := range strings.Fields(p.fields[0].String()) {
+			if i%2 == 0 {
+				switch tok {
+				case "÷":
+					c.Breaks = append(c.Breaks, true)
+				case "×":
+					c.Breaks = append(c.Breaks, false)
+				default:
+					return nil, fmt.Errorf("÷ or × is expected but got %v: line %v", tok, line)
+				}
+				continue
+			}
+			r, err := decodeHexToRune(tok)
+			if err != nil {
+				return nil, fmt.Errorf("%v: line %v", err, line)
+			}
+			c.Runes = append(c.Runes, r)
+		}
+		if len(c.Breaks) != len(c.Runes)+1 {
+			return nil, fmt.Errorf("a record must begin and end with ÷ or ×: line %v", line)
+		}
+
+		for _, m := range reBreakTestRule.FindAllStringSubmatch(p.comment, -1) {
+			c.Rules = append(c.Rules, m[1])
+		}
+		if len(c.Rules) != len(c.Breaks) {
+			c.Rules = nil
+		}
+
+		cases = append(cases, c)
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
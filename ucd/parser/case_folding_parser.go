@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseCaseFolding parses the CaseFolding.txt.
+func ParseCaseFolding(r io.Reader) (*property.CaseFolding, error) {
+	cf := &property.CaseFolding{
+		Simple: map[rune]rune{},
+		Full:   map[rune][]rune{},
+		Turkic: map[rune]rune{},
+	}
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		c, err := decodeHexToRune(p.fields[0].String())
+		if err != nil {
+			return nil, err
+		}
+		m, err := p.fields[2].codePointSequence()
+		if err != nil {
+			return nil, err
+		}
+		switch status := p.fields[1].String(); status {
+		case "C":
+			cf.Simple[c] = m[0]
+			cf.Full[c] = m
+		case "S":
+			cf.Simple[c] = m[0]
+		case "F":
+			cf.Full[c] = m
+		case "T":
+			cf.Turkic[c] = m[0]
+		default:
+			return nil, fmt.Errorf("unknown status of case folding: %v", status)
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return cf, nil
+}
@@ -7,8 +7,11 @@ import (
 )
 
 // ParseDerivedCoreProperties parses the DerivedCoreProperties.txt.
+//
+// The file abbreviates Indic_Conjunct_Break to InCB, and this function stores it under the long name.
 func ParseDerivedCoreProperties(r io.Reader) (*property.DerivedCoreProperties, error) {
 	props := map[property.PropertyName][]*property.CodePointRange{}
+	incb := map[property.PropertyValueSymbol][]*property.CodePointRange{}
 	p := newParser(r)
 	for p.parse() {
 		if len(p.fields) == 0 {
@@ -20,11 +23,12 @@ func ParseDerivedCoreProperties(r io.Reader) (*property.DerivedCoreProperties, e
 			return nil, err
 		}
 		name, _ := p.fields[1].name()
-		if name == property.PropNameAlphabetic || name == property.PropNameUppercase ||
-			name == property.PropNameLowercase || name == property.PropNameIDStart ||
-			name == property.PropNameIDContinue || name == property.PropNameXIDStart ||
-			name == property.PropNameXIDContinue {
+		switch {
+		case len(p.fields) == 2:
 			props[name] = append(props[name], cp)
+		case name == "InCB" || name == property.PropNameIndicConjunctBreak:
+			v := p.fields[2].normalizedSymbol()
+			incb[v] = append(incb[v], cp)
 		}
 	}
 	if p.err != nil {
@@ -32,6 +36,7 @@ func ParseDerivedCoreProperties(r io.Reader) (*property.DerivedCoreProperties, e
 	}
 
 	return &property.DerivedCoreProperties{
-		Entries: props,
+		Entries:            props,
+		IndicConjunctBreak: incb,
 	}, nil
 }
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"io"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseDerivedNormalizationProps parses the DerivedNormalizationProps.txt.
+//
+// This function keeps the binary properties and the quick check properties. The other properties such as
+// NFKC_Casefold are ignored.
+func ParseDerivedNormalizationProps(r io.Reader) (*property.DerivedNormalizationProps, error) {
+	props := map[property.PropertyName][]*property.CodePointRange{}
+	qc := map[property.PropertyName]map[property.PropertyValueSymbol][]*property.CodePointRange{}
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		cp, err := p.fields[0].codePointRange()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := p.fields[1].name()
+		switch {
+		case len(p.fields) == 2:
+			props[name] = append(props[name], cp)
+		case strings.HasSuffix(name.String(), "_QC"):
+			if qc[name] == nil {
+				qc[name] = map[property.PropertyValueSymbol][]*property.CodePointRange{}
+			}
+			v := p.fields[2].normalizedSymbol()
+			qc[name][v] = append(qc[name][v], cp)
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &property.DerivedNormalizationProps{
+		Entries:    props,
+		QuickCheck: qc,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseEastAsianWidth parses the EastAsianWidth.txt.
+func ParseEastAsianWidth(r io.Reader) (*property.EastAsianWidth, error) {
+	entries, defaults, err := parseEnumeratedPropertyWithDefaults(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property.EastAsianWidth{
+		Entries:  entries,
+		Defaults: defaults,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseEmojiData parses the emoji-data.txt.
+func ParseEmojiData(r io.Reader) (*property.EmojiData, error) {
+	props := map[property.PropertyName][]*property.CodePointRange{}
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		cp, err := p.fields[0].codePointRange()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := p.fields[1].name()
+		props[name] = append(props[name], cp)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &property.EmojiData{
+		Entries: props,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseGraphemeBreakProperty parses the GraphemeBreakProperty.txt.
+func ParseGraphemeBreakProperty(r io.Reader) (*property.GraphemeBreakProperty, error) {
+	entries, err := parseEnumeratedProperty(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property.GraphemeBreakProperty{
+		Entries: entries,
+	}, nil
+}
+
+// parseEnumeratedProperty parses a data file whose records consist of a code point range and a value of an
+// enumerated property, such as Scripts.txt.
+func parseEnumeratedProperty(r io.Reader) (map[property.PropertyValueSymbol][]*property.CodePointRange, error) {
+	entries, _, err := parseEnumeratedPropertyWithDefaults(r)
+	return entries, err
+}
+
+// parseEnumeratedPropertyWithDefaults parses a data file like parseEnumeratedProperty, and also returns the default
+// values the `@missing` lines specify in the order they appear.
+//
+// The format of the default values is explained in section 4.2.10 @missing Conventions in [UAX44].
+func parseEnumeratedPropertyWithDefaults(r io.Reader) (map[property.PropertyValueSymbol][]*property.CodePointRange, []*property.DefaultValue, error) {
+	entries := map[property.PropertyValueSymbol][]*property.CodePointRange{}
+	var defaults []*property.DefaultValue
+	p := newParser(r)
+	for p.parse() {
+		if len(p.defaultFields) >= 2 {
+			cp, err := p.defaultFields[0].codePointRange()
+			if err != nil {
+				return nil, nil, err
+			}
+			defaults = append(defaults, &property.DefaultValue{
+				Value: p.defaultFields[1].normalizedSymbol(),
+				CP:    cp,
+			})
+		}
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		cp, err := p.fields[0].codePointRange()
+		if err != nil {
+			return nil, nil, err
+		}
+		v := p.fields[1].normalizedSymbol()
+		entries[v] = append(entries[v], cp)
+	}
+	if p.err != nil {
+		return nil, nil, p.err
+	}
+
+	return entries, defaults, nil
+}
@@ -0,0 +1,20 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseLineBreak parses the LineBreak.txt.
+func ParseLineBreak(r io.Reader) (*property.LineBreak, error) {
+	entries, defaults, err := parseEnumeratedPropertyWithDefaults(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property.LineBreak{
+		Entries:  entries,
+		Defaults: defaults,
+	}, nil
+}
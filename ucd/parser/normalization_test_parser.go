@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NormalizationTestCase is a test case of NormalizationTest.txt. `Columns` holds the five columns of a record, that
+// is, source, NFC, NFD, NFKC, and NFKD in this order.
+type NormalizationTestCase struct {
+	Part    string
+	Line    int
+	Columns [5][]rune
+}
+
+// ParseNormalizationTest parses the NormalizationTest.txt.
+//
+// See the header of NormalizationTest.txt for more details on the format.
+func ParseNormalizationTest(r io.Reader) ([]*NormalizationTestCase, error) {
+	var cases []*NormalizationTestCase
+	var part string
+	line := 0
+	p := newParser(r)
+	for p.scanner.Scan() {
+		line++
+		p.parseRecord(p.scanner.Text())
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		// A line like `@Part1 # Character by character test` indicates the start of a part.
+		if strings.HasPrefix(p.fields[0].String(), "@") {
+			part = strings.TrimPrefix(p.fields[0].String(), "@")
+			continue
+		}
+
+		// Each record ends with a semicolon, so it has an empty sixth field.
+		if len(p.fields) < 5 {
+			return nil, fmt.Errorf("a record of NormalizationTest.txt must have 5 columns: line %v", line)
+		}
+		c := &NormalizationTestCase{
+			Part: part,
+			Line: line,
+		}
+		for i := 0; i < 5; i++ {
+			cps, err := p.fields[i].codePointSequence()
+			if err != nil {
+				return nil, fmt.Errorf("%v: line %v", err, line)
+			}
+			c.Columns[i] = cps
+		}
+		cases = append(cases, c)
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
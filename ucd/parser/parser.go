@@ -16,8 +16,6 @@ var (
 	reCodePointRange = regexp.MustCompile(`^([[:xdigit:]]+)(?:..([[:xdigit:]]+))?$`)
 
 	specialCommentPrefix = "# @missing:"
-
-	symValReplacer = strings.NewReplacer("_", "", "-", "", "\x20", "")
 )
 
 // parser parses data files of UCD.
@@ -27,10 +25,15 @@ var (
 // We can perform more specific parsing for each file by implementing a dedicated parser that wraps this parser.
 //
 // See section 4.2 File Format Conventions in [UAX44] for more information on the file format.
+//
+// `heading` holds the last comment line that is neither a record nor a separator line like `# =====`. Some files
+// such as PropertyAliases.txt group records under such headings.
 type parser struct {
 	scanner       *bufio.Scanner
 	fields        []field
 	defaultFields []field
+	comment       string
+	heading       string
 	err           error
 
 	fieldBuf        []field
@@ -60,6 +63,7 @@ func (p *parser) parseRecord(src string) {
 	ms := reLine.FindStringSubmatch(src)
 	mFields := ms[1]
 	mComment := ms[2]
+	p.comment = mComment
 	if mFields != "" {
 		p.fields = parseFields(p.fieldBuf, mFields)
 	} else {
@@ -70,6 +74,11 @@ func (p *parser) parseRecord(src string) {
 	} else {
 		p.defaultFields = nil
 	}
+	if mFields == "" && p.defaultFields == nil {
+		if h := strings.TrimSpace(strings.TrimPrefix(mComment, "#")); h != "" && strings.Trim(h, "=") != "" {
+			p.heading = h
+		}
+	}
 }
 
 func parseFields(buf []field, src string) []field {
@@ -108,6 +117,24 @@ func (f field) codePointRange() (*property.CodePointRange, error) {
 	return property.NewCodePointRange(from, to), nil
 }
 
+// codePointSequence returns a sequence of code points delimited by spaces. An empty field results in an empty
+// sequence.
+func (f field) codePointSequence() ([]rune, error) {
+	hs := strings.Fields(string(f))
+	if len(hs) == 0 {
+		return nil, nil
+	}
+	cps := make([]rune, len(hs))
+	for i, h := range hs {
+		c, err := decodeHexToRune(h)
+		if err != nil {
+			return nil, err
+		}
+		cps[i] = c
+	}
+	return cps, nil
+}
+
 func decodeHexToRune(hexCodePoint string) (rune, error) {
 	h := hexCodePoint
 	if len(h)%2 != 0 {
@@ -169,9 +196,5 @@ func (f field) symbol() property.PropertyValueSymbol {
 //
 // The normalization algorithm follows UAX44-LM3 defined section 5.9.3 Matching Symbolic Values in [UAX44].
 func (f field) normalizedSymbol() property.PropertyValueSymbol {
-	sym := strings.ToLower(symValReplacer.Replace(string(f)))
-	if sym == "is" {
-		return property.NewSymbolPropertyValue(sym)
-	}
-	return property.NewSymbolPropertyValue(strings.TrimPrefix(sym, "is"))
+	return property.NormalizeSymbol(string(f))
 }
@@ -302,3 +302,301 @@ func TestField_normalizedSymbol(t *testing.T) {
 		})
 	}
 }
+
+func TestParseUnicodeData(t *testing.T) {
+	src := `
+0041;LATIN CAPITAL LETTER A;Lu;0;L;;;;;N;;;;0061;
+00BD;VULGAR FRACTION ONE HALF;No;0;ON;<fraction> 0031 2044 0032;;;1/2;N;FRACTION ONE HALF;;;;
+01C5;LATIN CAPITAL LETTER D WITH SMALL LETTER Z WITH CARON;Lt;0;L;<compat> 0044 017E;;;;N;LATIN LETTER CAPITAL D SMALL Z HACEK;;01C4;01C6;01C5
+0300;COMBINING GRAVE ACCENT;Mn;230;NSM;;;;;N;NON-SPACING GRAVE;;;;
+0660;ARABIC-INDIC DIGIT ZERO;Nd;0;AN;;0;0;0;N;;;;;
+2126;OHM SIGN;Lu;0;L;03A9;;;;N;OHM;;;03C9;
+4E00;<CJK Ideograph, First>;Lo;0;L;;;;;N;;;;;
+9FFC;<CJK Ideograph, Last>;Lo;0;L;;;;;N;;;;;
+`
+	ud, err := ParseUnicodeData(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCodePointRanges(t, ud.GeneralCategory["lo"], []*property.CodePointRange{
+		property.NewCodePointRange(0x4E00, 0x9FFC),
+	})
+	testCodePointRanges(t, ud.CanonicalCombiningClass["0"], []*property.CodePointRange{
+		property.NewCodePointRange(0x0041, 0x0041),
+		property.NewCodePointRange(0x00BD, 0x00BD),
+		property.NewCodePointRange(0x01C5, 0x01C5),
+		property.NewCodePointRange(0x0660, 0x0660),
+		property.NewCodePointRange(0x2126, 0x2126),
+		property.NewCodePointRange(0x4E00, 0x9FFC),
+	})
+	testCodePointRanges(t, ud.CanonicalCombiningClass["230"], []*property.CodePointRange{
+		property.NewCodePointRange(0x0300, 0x0300),
+	})
+	testCodePointRanges(t, ud.BidiClass["an"], []*property.CodePointRange{
+		property.NewCodePointRange(0x0660, 0x0660),
+	})
+
+	if d := ud.Decomposition[0x00BD]; d == nil || d.Type != "fraction" || fmt.Sprint(d.Mapping) != fmt.Sprint([]rune{0x0031, 0x2044, 0x0032}) {
+		t.Fatalf("unexpected decomposition: %#v", d)
+	}
+	if d := ud.Decomposition[0x2126]; d == nil || d.Type != "canonical" || fmt.Sprint(d.Mapping) != fmt.Sprint([]rune{0x03A9}) {
+		t.Fatalf("unexpected decomposition: %#v", d)
+	}
+	if n := ud.Numeric[0x00BD]; n == nil || n.Type != "nu" || n.Value != "1/2" {
+		t.Fatalf("unexpected numeric: %#v", n)
+	}
+	if n := ud.Numeric[0x0660]; n == nil || n.Type != "de" || n.Value != "0" {
+		t.Fatalf("unexpected numeric: %#v", n)
+	}
+	if na1 := ud.Unicode1Name[0x2126]; na1 != "OHM" {
+		t.Fatalf("unexpected Unicode_1_Name: %v", na1)
+	}
+	if ud.SimpleUppercaseMapping[0x01C5] != 0x01C4 || ud.SimpleLowercaseMapping[0x01C5] != 0x01C6 || ud.SimpleTitlecaseMapping[0x01C5] != 0x01C5 {
+		t.Fatalf("unexpected simple case mappings")
+	}
+	if _, ok := ud.Name["LATIN CAPITAL LETTER A"]; !ok {
+		t.Fatalf("a name was not found")
+	}
+}
+
+func TestParseUnicodeData_BrokenRange(t *testing.T) {
+	tests := []string{
+		`
+4E00;<CJK Ideograph, First>;Lo;0;L;;;;;N;;;;;
+4E01;CJK UNIFIED IDEOGRAPH-4E01;Lo;0;L;;;;;N;;;;;
+`,
+		`
+9FFC;<CJK Ideograph, Last>;Lo;0;L;;;;;N;;;;;
+`,
+		`
+4E00;<CJK Ideograph, First>;Lo;0;L;;;;;N;;;;;
+`,
+	}
+	for i, src := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			_, err := ParseUnicodeData(strings.NewReader(src))
+			if err == nil {
+				t.Fatal("an error was expected")
+			}
+		})
+	}
+}
+
+func testCodePointRanges(t *testing.T, actual, expected []*property.CodePointRange) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("unexpected code point ranges: want: %v, got: %v", expected, actual)
+	}
+	for i, cp := range actual {
+		if *cp != *expected[i] {
+			t.Fatalf("unexpected code point ranges: want: %v, got: %v", expected, actual)
+		}
+	}
+}
+
+func TestParseBreakTest(t *testing.T) {
+	src := `
+# GraphemeBreakTest.txt
+÷ 0020 × 0308 ÷ 1F1E6 ÷	#  ÷ [0.2] SPACE (Other) × [9.0] COMBINING DIAERESIS (Extend_ExtCccZwj) ÷ [999.0] REGIONAL INDICATOR SYMBOL LETTER A (RI) ÷ [0.3]
+× 0023 ÷
+`
+	cases, err := ParseBreakTest(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("unexpected number of test cases: want: 2, got: %v", len(cases))
+	}
+
+	c := cases[0]
+	if c.Line != 3 {
+		t.Fatalf("unexpected line: want: 3, got: %v", c.Line)
+	}
+	if fmt.Sprintf("%X", c.Runes) != fmt.Sprintf("%X", []rune{0x0020, 0x0308, 0x1F1E6}) {
+		t.Fatalf("unexpected code points: %X", c.Runes)
+	}
+	if fmt.Sprint(c.Breaks) != fmt.Sprint([]bool{true, false, true, true}) {
+		t.Fatalf("unexpected breaks: %v", c.Breaks)
+	}
+	if fmt.Sprint(c.Rules) != fmt.Sprint([]string{"0.2", "9.0", "999.0", "0.3"}) {
+		t.Fatalf("unexpected rules: %v", c.Rules)
+	}
+
+	c = cases[1]
+	if fmt.Sprint(c.Breaks) != fmt.Sprint([]bool{false, true}) || c.Rules != nil {
+		t.Fatalf("unexpected test case: %#v", c)
+	}
+}
+
+func TestParseBidiTest(t *testing.T) {
+	src := `
+# BidiTest.txt
+@Levels:	x 1
+@Reorder:	1
+LRE R; 7
+@Levels:	0
+@Reorder:	0
+L; 2
+`
+	cases, err := ParseBidiTest(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("unexpected number of test cases: want: 2, got: %v", len(cases))
+	}
+
+	c := cases[0]
+	if c.Line != 5 {
+		t.Fatalf("unexpected line: want: 5, got: %v", c.Line)
+	}
+	if fmt.Sprint(c.Classes) != "[lre r]" {
+		t.Fatalf("unexpected classes: %v", c.Classes)
+	}
+	if fmt.Sprint(c.Directions) != fmt.Sprint([]int{BidiDirectionAuto, BidiDirectionLTR, BidiDirectionRTL}) {
+		t.Fatalf("unexpected directions: %v", c.Directions)
+	}
+	if fmt.Sprint(c.Levels) != "[-1 1]" || fmt.Sprint(c.Reorder) != "[1]" {
+		t.Fatalf("unexpected levels or reorder: %v, %v", c.Levels, c.Reorder)
+	}
+
+	c = cases[1]
+	if fmt.Sprint(c.Directions) != fmt.Sprint([]int{BidiDirectionLTR}) || fmt.Sprint(c.Levels) != "[0]" {
+		t.Fatalf("unexpected test case: %#v", c)
+	}
+}
+
+func TestParseBidiCharacterTest(t *testing.T) {
+	src := `
+# BidiCharacterTest.txt
+05D0 0028 0061 0029;2;1;1 1 2 1;3 2 1 0
+`
+	cases, err := ParseBidiCharacterTest(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 1 {
+		t.Fatalf("unexpected number of test cases: want: 1, got: %v", len(cases))
+	}
+
+	c := cases[0]
+	if fmt.Sprintf("%X", c.Runes) != fmt.Sprintf("%X", []rune{0x05D0, 0x0028, 0x0061, 0x0029}) {
+		t.Fatalf("unexpected code points: %X", c.Runes)
+	}
+	if c.Direction != BidiDirectionAuto || c.ParagraphLevel != 1 {
+		t.Fatalf("unexpected direction or paragraph level: %v, %v", c.Direction, c.ParagraphLevel)
+	}
+	if fmt.Sprint(c.Levels) != "[1 1 2 1]" || fmt.Sprint(c.Reorder) != "[3 2 1 0]" {
+		t.Fatalf("unexpected levels or reorder: %v, %v", c.Levels, c.Reorder)
+	}
+}
+
+func TestParseSpecialCasing(t *testing.T) {
+	src := `
+00DF; 00DF; 0053 0073; 0053 0053; # LATIN SMALL LETTER SHARP S
+0307; ; 0307; 0307; tr After_I; # COMBINING DOT ABOVE
+`
+	sc, err := ParseSpecialCasing(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Entries) != 2 {
+		t.Fatalf("unexpected number of entries: want: 2, got: %v", len(sc.Entries))
+	}
+
+	e := sc.Entries[0]
+	if e.CP != 0x00DF || fmt.Sprintf("%X", e.Title) != "[53 73]" || fmt.Sprintf("%X", e.Upper) != "[53 53]" || e.Conditions != nil {
+		t.Fatalf("unexpected entry: %#v", e)
+	}
+
+	e = sc.Entries[1]
+	if e.CP != 0x0307 || e.Lower != nil || fmt.Sprint(e.Conditions) != "[tr After_I]" {
+		t.Fatalf("unexpected entry: %#v", e)
+	}
+}
+
+func TestParsePropList(t *testing.T) {
+	src := `
+0009..000D    ; White_Space # Cc   [5] <control-0009>..<control-000D>
+0020          ; White_Space # Zs       SPACE
+002D          ; Dash # Pd       HYPHEN-MINUS
+1F1E6..1F1FF  ; Regional_Indicator # So  [26] REGIONAL INDICATOR SYMBOL LETTER A..REGIONAL INDICATOR SYMBOL LETTER Z
+`
+	pl, err := ParsePropList(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[property.PropertyName]int{
+		property.PropNameWhiteSpace:        2,
+		property.PropNameDash:              1,
+		property.PropNameRegionalIndicator: 1,
+	}
+	if len(pl.Entries) != len(expected) {
+		t.Fatalf("unexpected number of properties: want: %v, got: %v", len(expected), len(pl.Entries))
+	}
+	for name, n := range expected {
+		if len(pl.Entries[name]) != n {
+			t.Fatalf("unexpected number of ranges of %v: want: %v, got: %v", name, n, len(pl.Entries[name]))
+		}
+	}
+	if !pl.Entries[property.PropNameRegionalIndicator][0].Contain(0x1F1FF) {
+		t.Fatalf("Regional_Indicator must contain U+1F1FF")
+	}
+}
+
+func TestParseDerivedCoreProperties(t *testing.T) {
+	src := `
+002B          ; Math # Sm       PLUS SIGN
+0041..005A    ; XID_Start # L&  [26] LATIN CAPITAL LETTER A..LATIN CAPITAL LETTER Z
+00AD          ; Default_Ignorable_Code_Point # Cf       SOFT HYPHEN
+094D          ; InCB; Linker # Mn       DEVANAGARI SIGN VIRAMA
+0915..0939    ; InCB; Consonant # Lo  [37] DEVANAGARI LETTER KA..DEVANAGARI LETTER HA
+`
+	dcp, err := ParseDerivedCoreProperties(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []property.PropertyName{property.PropNameMath, property.PropNameXIDStart, property.PropNameDefaultIgnorableCodePoint} {
+		if len(dcp.Entries[name]) != 1 {
+			t.Fatalf("unexpected number of ranges of %v: want: 1, got: %v", name, len(dcp.Entries[name]))
+		}
+	}
+	if len(dcp.Entries) != 3 {
+		t.Fatalf("unexpected number of binary properties: want: 3, got: %v", len(dcp.Entries))
+	}
+	if len(dcp.IndicConjunctBreak["linker"]) != 1 || len(dcp.IndicConjunctBreak["consonant"]) != 1 {
+		t.Fatalf("unexpected Indic_Conjunct_Break: %#v", dcp.IndicConjunctBreak)
+	}
+}
+
+func TestParsePropertyAliases(t *testing.T) {
+	src := `
+# PropertyAliases-13.0.0.txt
+
+# ================================================
+# Numeric Properties
+# ================================================
+nv                       ; Numeric_Value
+# ================================================
+# Binary Properties
+# ================================================
+WSpace                   ; White_Space                 ; space
+`
+	aliases, err := ParsePropertyAliases(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aliases.Aliases) != 2 {
+		t.Fatalf("unexpected number of aliases: want: 2, got: %v", len(aliases.Aliases))
+	}
+	if a := aliases.Aliases[0]; a.Long != "Numeric_Value" || a.Type != property.PropertyTypeNumeric {
+		t.Fatalf("unexpected alias: %#v", a)
+	}
+	if a := aliases.Aliases[1]; a.Long != "White_Space" || a.Type != property.PropertyTypeBinary || len(a.Others) != 1 {
+		t.Fatalf("unexpected alias: %#v", a)
+	}
+}
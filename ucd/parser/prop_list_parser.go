@@ -8,7 +8,7 @@ import (
 
 // ParsePropList parses the PropList.txt.
 func ParsePropList(r io.Reader) (*property.PropList, error) {
-	var ws []*property.CodePointRange
+	props := map[property.PropertyName][]*property.CodePointRange{}
 	p := newParser(r)
 	for p.parse() {
 		if len(p.fields) == 0 {
@@ -19,16 +19,14 @@ func ParsePropList(r io.Reader) (*property.PropList, error) {
 		if err != nil {
 			return nil, err
 		}
-
-		if propName, _ := p.fields[1].name(); propName == property.PropNameWhiteSpace {
-			ws = append(ws, cp)
-		}
+		name, _ := p.fields[1].name()
+		props[name] = append(props[name], cp)
 	}
 	if p.err != nil {
 		return nil, p.err
 	}
 
 	return &property.PropList{
-		WhiteSpace: ws,
+		Entries: props,
 	}, nil
 }
@@ -2,11 +2,13 @@ package parser
 
 import (
 	"io"
+	"strings"
 
 	"github.com/nihei9/ucdx/ucd/property"
 )
 
-// ParsePropertyAliases parses the PropertyAliases.txt.
+// ParsePropertyAliases parses the PropertyAliases.txt. The types of the properties come from the headings like
+// `# Binary Properties` the file groups them under.
 func ParsePropertyAliases(r io.Reader) (*property.PropertyAliases, error) {
 	aliases := []*property.PropertyAlias{}
 
@@ -25,10 +27,12 @@ func ParsePropertyAliases(r io.Reader) (*property.PropertyAliases, error) {
 				others = append(others, o)
 			}
 		}
+		typ, _ := property.ParsePropertyType(strings.TrimSuffix(p.heading, " Properties"))
 		aliases = append(aliases, &property.PropertyAlias{
 			Abb:    abb,
 			Long:   long,
 			Others: others,
+			Type:   typ,
 		})
 	}
 	if p.err != nil {
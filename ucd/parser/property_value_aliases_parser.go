@@ -33,7 +33,8 @@ func ParsePropertyValueAliases(r io.Reader) (*property.PropertyValueAliases, err
 
 		// The format of the default values is explained in section 4.2.10 @missing Conventions in [UAX44].
 		if len(p.defaultFields) > 0 {
-			if propName, _ := p.defaultFields[1].name(); propName == property.PropNameGeneralCategory {
+			propName, _ := p.defaultFields[1].name()
+			if _, ok := defaultValues[propName]; !ok {
 				cp, err := p.defaultFields[0].codePointRange()
 				if err != nil {
 					return nil, err
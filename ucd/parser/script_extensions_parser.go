@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"io"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseScriptExtensions parses the ScriptExtensions.txt.
+func ParseScriptExtensions(r io.Reader) (*property.ScriptExtensions, error) {
+	var entries []*property.ScriptExtensionsEntry
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		cp, err := p.fields[0].codePointRange()
+		if err != nil {
+			return nil, err
+		}
+		// The second field is a space-delimited list of the Script property values.
+		// See section 5.7.3 Script_Extensions in [UAX44].
+		var scs []property.PropertyValueSymbol
+		for _, sc := range strings.Fields(p.fields[1].String()) {
+			scs = append(scs, field(sc).normalizedSymbol())
+		}
+		entries = append(entries, &property.ScriptExtensionsEntry{
+			CP:      cp,
+			Scripts: scs,
+		})
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &property.ScriptExtensions{
+		Entries: entries,
+	}, nil
+}
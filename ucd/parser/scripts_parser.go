@@ -0,0 +1,19 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseScripts parses the Scripts.txt.
+func ParseScripts(r io.Reader) (*property.Scripts, error) {
+	entries, err := parseEnumeratedProperty(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property.Scripts{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseSentenceBreakProperty parses the SentenceBreakProperty.txt.
+func ParseSentenceBreakProperty(r io.Reader) (*property.SentenceBreakProperty, error) {
+	entries, err := parseEnumeratedProperty(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property.SentenceBreakProperty{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"io"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseSpecialCasing parses the SpecialCasing.txt. Each record has the following fields:
+//
+//	<code>; <lower>; <title>; <upper>; (<condition_list>;)? # <comment>
+func ParseSpecialCasing(r io.Reader) (*property.SpecialCasing, error) {
+	var entries []*property.SpecialCasingEntry
+	p := newParser(r)
+	for p.parse() {
+		if len(p.fields) == 0 {
+			continue
+		}
+
+		c, err := decodeHexToRune(p.fields[0].String())
+		if err != nil {
+			return nil, err
+		}
+		e := &property.SpecialCasingEntry{
+			CP: c,
+		}
+		e.Lower, err = p.fields[1].codePointSequence()
+		if err != nil {
+			return nil, err
+		}
+		e.Title, err = p.fields[2].codePointSequence()
+		if err != nil {
+			return nil, err
+		}
+		e.Upper, err = p.fields[3].codePointSequence()
+		if err != nil {
+			return nil, err
+		}
+		if len(p.fields) > 4 && p.fields[4] != "" {
+			e.Conditions = strings.Fields(p.fields[4].String())
+		}
+		entries = append(entries, e)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &property.SpecialCasing{
+		Entries: entries,
+	}, nil
+}
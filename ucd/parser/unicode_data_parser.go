@@ -3,62 +3,201 @@ package parser
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/nihei9/ucdx/ucd/property"
 )
 
+// unicodeDataRecord is a parsed record of UnicodeData.txt.
+//
+// See section 4.2.8 UnicodeData.txt in [UAX44] for more details on each field.
+type unicodeDataRecord struct {
+	name          property.PropertyName
+	gc            property.PropertyValueSymbol
+	ccc           property.PropertyValueSymbol
+	bc            property.PropertyValueSymbol
+	decomposition *property.Decomposition
+	numeric       *property.Numeric
+	bidiMirrored  property.PropertyValueBinary
+	unicode1Name  property.PropertyName
+	isoComment    property.PropertyValueString
+	upper         []rune
+	lower         []rune
+	title         []rune
+}
+
 // ParseUnicodeData parses the UnicodeData.txt.
 func ParseUnicodeData(r io.Reader) (*property.UnicodeData, error) {
-	ud := &property.UnicodeData{
-		Name:            map[property.PropertyName]*property.CodePointRange{},
-		GeneralCategory: map[property.PropertyValueSymbol][]*property.CodePointRange{},
-	}
+	ud := property.NewUnicodeData()
 
 	inRange := false
 	var firstCP rune
-	var firstGC property.PropertyValueSymbol
+	var firstRec *unicodeDataRecord
 	p := newParser(r)
 	for p.parse() {
 		if len(p.fields) == 0 {
 			continue
 		}
+		if len(p.fields) != 15 {
+			return nil, fmt.Errorf("a record of UnicodeData.txt must have 15 fields: %v", p.scanner.Text())
+		}
 
 		cp, err := p.fields[0].codePointRange()
 		if err != nil {
 			return nil, err
 		}
 
+		// Code point ranges are represented as a pair of records. See section 4.2.3 Code Point Ranges in [UAX44].
 		if inRange {
 			if !p.fields[1].rangeLast() {
-				// TODO: ERROR
-				return nil, fmt.Errorf("")
+				return nil, fmt.Errorf("a record following a start of a code point range must be a last of the range: %v", p.scanner.Text())
 			}
 			lastCP, _ := cp.Range()
-			ud.AddGC(firstGC, property.NewCodePointRange(firstCP, lastCP))
+			addUnicodeDataRecord(ud, firstRec, property.NewCodePointRange(firstCP, lastCP))
 			inRange = false
 
 			continue
 		}
-		name, ok := p.fields[1].name()
-		if ok {
-			ud.Name[name] = cp
-		} else {
-			if p.fields[1].rangeStart() {
-				inRange = true
-				firstCP, _ = cp.Range()
-			}
-		}
 
-		gc := p.fields[2].normalizedSymbol()
-		if inRange {
-			firstGC = gc
-		} else {
-			ud.AddGC(gc, cp)
+		rec, err := parseUnicodeDataRecord(p.fields)
+		if err != nil {
+			return nil, err
+		}
+		if p.fields[1].rangeStart() {
+			inRange = true
+			firstCP, _ = cp.Range()
+			firstRec = rec
+			continue
+		}
+		if p.fields[1].rangeLast() {
+			return nil, fmt.Errorf("a last of a code point range appeared without its start: %v", p.scanner.Text())
 		}
+		addUnicodeDataRecord(ud, rec, cp)
 	}
 	if p.err != nil {
 		return nil, p.err
 	}
+	if inRange {
+		return nil, fmt.Errorf("a code point range starting at %X is not closed", firstCP)
+	}
 
 	return ud, nil
 }
+
+func parseUnicodeDataRecord(fields []field) (*unicodeDataRecord, error) {
+	rec := &unicodeDataRecord{}
+
+	rec.name, _ = fields[1].name()
+	rec.gc = fields[2].normalizedSymbol()
+	rec.ccc = fields[3].normalizedSymbol()
+	rec.bc = fields[4].normalizedSymbol()
+
+	if fields[5] != "" {
+		d, err := fields[5].decomposition()
+		if err != nil {
+			return nil, err
+		}
+		rec.decomposition = d
+	}
+
+	// Fields 6, 7, and 8 represent the Numeric_Type and the Numeric_Value properties. Field 6 is set only for
+	// decimal digits, field 7 is set for decimal digits and digits, and field 8 is set for all numeric characters.
+	var nt property.PropertyValueSymbol
+	switch {
+	case fields[6] != "":
+		nt = "de"
+	case fields[7] != "":
+		nt = "di"
+	case fields[8] != "":
+		nt = "nu"
+	}
+	if nt != "" {
+		rec.numeric = &property.Numeric{
+			Type:  nt,
+			Value: property.PropertyValueNumeric(fields[8]),
+		}
+	}
+
+	rec.bidiMirrored = fields[9] == "Y"
+	rec.unicode1Name, _ = fields[10].name()
+	rec.isoComment = property.PropertyValueString(fields[11])
+
+	var err error
+	rec.upper, err = fields[12].codePointSequence()
+	if err != nil {
+		return nil, err
+	}
+	rec.lower, err = fields[13].codePointSequence()
+	if err != nil {
+		return nil, err
+	}
+	rec.title, err = fields[14].codePointSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+func addUnicodeDataRecord(ud *property.UnicodeData, rec *unicodeDataRecord, cp *property.CodePointRange) {
+	ud.AddGC(rec.gc, cp)
+	ud.AddCCC(rec.ccc, cp)
+	ud.AddBidiClass(rec.bc, cp)
+	ud.AddBidiMirrored(rec.bidiMirrored, cp)
+
+	// The other properties are defined for each code point, and the records representing code point ranges never
+	// have them. Thus, we record them only for the first code point of a range.
+	c, _ := cp.Range()
+	if rec.name != "" {
+		ud.Name[rec.name] = cp
+	}
+	if rec.decomposition != nil {
+		ud.Decomposition[c] = rec.decomposition
+	}
+	if rec.numeric != nil {
+		ud.Numeric[c] = rec.numeric
+	}
+	if rec.unicode1Name != "" {
+		ud.Unicode1Name[c] = rec.unicode1Name
+	}
+	if rec.isoComment != "" {
+		ud.ISOComment[c] = rec.isoComment
+	}
+	if len(rec.upper) > 0 {
+		ud.SimpleUppercaseMapping[c] = rec.upper[0]
+	}
+	if len(rec.lower) > 0 {
+		ud.SimpleLowercaseMapping[c] = rec.lower[0]
+	}
+	if len(rec.title) > 0 {
+		ud.SimpleTitlecaseMapping[c] = rec.title[0]
+	}
+}
+
+// decomposition returns a value parsed as the Decomposition_Type and the Decomposition_Mapping properties.
+//
+// The field consists of an optional formatting tag enclosed in angle brackets and a sequence of code points.
+// When the tag is omitted, the decomposition is canonical.
+func (f field) decomposition() (*property.Decomposition, error) {
+	s := string(f)
+	dt := property.PropertyValueSymbol("canonical")
+	if strings.HasPrefix(s, "<") {
+		i := strings.Index(s, ">")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid decomposition: %v", s)
+		}
+		dt = field(s[1:i]).normalizedSymbol()
+		s = s[i+1:]
+	}
+	dm, err := field(strings.TrimSpace(s)).codePointSequence()
+	if err != nil {
+		return nil, err
+	}
+	if len(dm) == 0 {
+		return nil, fmt.Errorf("invalid decomposition: %v", f)
+	}
+	return &property.Decomposition{
+		Type:    dt,
+		Mapping: dm,
+	}, nil
+}
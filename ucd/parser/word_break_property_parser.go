@@ -0,0 +1,19 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// ParseWordBreakProperty parses the WordBreakProperty.txt.
+func ParseWordBreakProperty(r io.Reader) (*property.WordBreakProperty, error) {
+	entries, err := parseEnumeratedProperty(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property.WordBreakProperty{
+		Entries: entries,
+	}, nil
+}
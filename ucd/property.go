@@ -2,7 +2,9 @@ package ucd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/nihei9/ucdx/ucd/property"
 )
@@ -11,6 +13,8 @@ type PropertySet struct {
 	CP                    rune                                             `json:"code_point"`
 	Properties            map[property.PropertyName]property.PropertyValue `json:"properties"`
 	GeneralCategoryGroups []property.PropertyValueSymbol                   `json:"general_category_group"`
+	ScriptLongName        property.PropertyValueSymbol                     `json:"script_long_name"`
+	BlockLongName         property.PropertyValueSymbol                     `json:"block_long_name"`
 }
 
 func (s *PropertySet) Lookup(propName property.PropertyName) *property.Property {
@@ -22,34 +26,390 @@ func (s *PropertySet) Lookup(propName property.PropertyName) *property.Property
 }
 
 type UCD struct {
-	UnicodeData           *property.UnicodeData
-	NameAliases           *property.NameAliases
-	DerivedCoreProperties *property.DerivedCoreProperties
-	PropertyAliases       *property.PropertyAliases
-	PropertyValueAliases  *property.PropertyValueAliases
-	PropList              *property.PropList
-	Unification           *property.Unification
+	UnicodeData               *property.UnicodeData
+	NameAliases               *property.NameAliases
+	DerivedCoreProperties     *property.DerivedCoreProperties
+	PropertyAliases           *property.PropertyAliases
+	PropertyValueAliases      *property.PropertyValueAliases
+	PropList                  *property.PropList
+	Scripts                   *property.Scripts
+	ScriptExtensions          *property.ScriptExtensions
+	Blocks                    *property.Blocks
+	DerivedNormalizationProps *property.DerivedNormalizationProps
+	LineBreak                 *property.LineBreak
+	EastAsianWidth            *property.EastAsianWidth
+	BidiBrackets              *property.BidiBrackets
+	BidiMirroring             *property.BidiMirroring
+	CaseFolding               *property.CaseFolding
+	SpecialCasing             *property.SpecialCasing
+	GraphemeBreakProperty     *property.GraphemeBreakProperty
+	WordBreakProperty         *property.WordBreakProperty
+	SentenceBreakProperty     *property.SentenceBreakProperty
+	EmojiData                 *property.EmojiData
+	Unification               *property.Unification
+
+	idx *ucdIndex
+}
+
+// propertyLookups maps the properties UCD can look up to functions returning their values.
+var propertyLookups = map[property.PropertyName]func(u *UCD, c rune) property.PropertyValue{
+	property.PropNameName: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupName(c)
+	},
+	property.PropNameNameAlias: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupNameAlias(c)
+	},
+	property.PropNameGeneralCategory: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupGeneralCategory(c)
+	},
+	property.PropNameCanonicalCombiningClass: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupCanonicalCombiningClass(c)
+	},
+	property.PropNameBidiClass: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBidiClass(c)
+	},
+	property.PropNameDecompositionType: func(u *UCD, c rune) property.PropertyValue {
+		dt, _ := u.lookupDecomposition(c)
+		return dt
+	},
+	property.PropNameDecompositionMapping: func(u *UCD, c rune) property.PropertyValue {
+		_, dm := u.lookupDecomposition(c)
+		return dm
+	},
+	property.PropNameNumericType: func(u *UCD, c rune) property.PropertyValue {
+		nt, _ := u.lookupNumeric(c)
+		return nt
+	},
+	property.PropNameNumericValue: func(u *UCD, c rune) property.PropertyValue {
+		_, nv := u.lookupNumeric(c)
+		return nv
+	},
+	property.PropNameBidiMirrored: func(u *UCD, c rune) property.PropertyValue {
+		return u.isBidiMirrored(c)
+	},
+	property.PropNameBidiMirroringGlyph: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBidiMirroringGlyph(c)
+	},
+	property.PropNameBidiPairedBracket: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBidiPairedBracket(c)
+	},
+	property.PropNameBidiPairedBracketType: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBidiPairedBracketType(c)
+	},
+	property.PropNameUnicode1Name: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupUnicode1Name(c)
+	},
+	property.PropNameISOComment: func(u *UCD, c rune) property.PropertyValue {
+		return u.UnicodeData.ISOComment[c]
+	},
+	property.PropNameSimpleUppercaseMapping: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupSimpleUppercaseMapping(c)
+	},
+	property.PropNameSimpleLowercaseMapping: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupSimpleLowercaseMapping(c)
+	},
+	property.PropNameSimpleTitlecaseMapping: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupSimpleTitlecaseMapping(c)
+	},
+	property.PropNameUppercaseMapping: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupUppercaseMapping(c)
+	},
+	property.PropNameLowercaseMapping: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupLowercaseMapping(c)
+	},
+	property.PropNameTitlecaseMapping: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupTitlecaseMapping(c)
+	},
+	property.PropNameSimpleCaseFolding: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupSimpleCaseFolding(c)
+	},
+	property.PropNameCaseFolding: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupCaseFolding(c)
+	},
+	property.PropNameScript: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupScript(c).Abb
+	},
+	property.PropNameScriptExtensions: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupScriptExtensions(c, u.lookupScript(c))
+	},
+	property.PropNameBlock: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBlock(c).Abb
+	},
+	property.PropNameLineBreak: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupLineBreak(c)
+	},
+	property.PropNameEastAsianWidth: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupEastAsianWidth(c)
+	},
+	property.PropNameIndicConjunctBreak: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupIndicConjunctBreak(c)
+	},
+	property.PropNameGraphemeClusterBreak: func(u *UCD, c rune) property.PropertyValue {
+		return lookupBreakProperty(u.index().graphemeClusterBreak, c)
+	},
+	property.PropNameWordBreak: func(u *UCD, c rune) property.PropertyValue {
+		return lookupBreakProperty(u.index().wordBreak, c)
+	},
+	property.PropNameSentenceBreak: func(u *UCD, c rune) property.PropertyValue {
+		return lookupBreakProperty(u.index().sentenceBreak, c)
+	},
+	property.PropNameExtendedPictographic: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBinaryProperty(property.PropNameExtendedPictographic, c)
+	},
+	property.PropNameFullCompositionExclusion: func(u *UCD, c rune) property.PropertyValue {
+		return u.lookupBinaryProperty(property.PropNameFullCompositionExclusion, c)
+	},
+}
+
+func init() {
+	for _, name := range property.DerivedCorePropertyNames {
+		name := name
+		propertyLookups[name] = func(u *UCD, c rune) property.PropertyValue {
+			return u.lookupBinaryProperty(name, c)
+		}
+	}
+	for _, name := range property.PropListPropertyNames {
+		name := name
+		propertyLookups[name] = func(u *UCD, c rune) property.PropertyValue {
+			return u.lookupBinaryProperty(name, c)
+		}
+	}
+}
+
+// DefaultPropertyNames lists the properties AnalizeCodePoint looks up.
+var DefaultPropertyNames = func() []property.PropertyName {
+	names := []property.PropertyName{
+		property.PropNameName,
+		property.PropNameNameAlias,
+		property.PropNameUnicode1Name,
+		property.PropNameGeneralCategory,
+		property.PropNameCanonicalCombiningClass,
+		property.PropNameBidiClass,
+		property.PropNameBidiMirrored,
+		property.PropNameBidiMirroringGlyph,
+		property.PropNameBidiPairedBracket,
+		property.PropNameBidiPairedBracketType,
+		property.PropNameDecompositionType,
+		property.PropNameDecompositionMapping,
+		property.PropNameNumericType,
+		property.PropNameNumericValue,
+		property.PropNameSimpleUppercaseMapping,
+		property.PropNameSimpleLowercaseMapping,
+		property.PropNameSimpleTitlecaseMapping,
+		property.PropNameUppercaseMapping,
+		property.PropNameLowercaseMapping,
+		property.PropNameTitlecaseMapping,
+		property.PropNameSimpleCaseFolding,
+		property.PropNameCaseFolding,
+		property.PropNameISOComment,
+	}
+	names = append(names, property.DerivedCorePropertyNames...)
+	names = append(names, property.PropListPropertyNames...)
+	names = append(names,
+		property.PropNameFullCompositionExclusion,
+		property.PropNameExtendedPictographic,
+		property.PropNameScript,
+		property.PropNameScriptExtensions,
+		property.PropNameBlock,
+		property.PropNameLineBreak,
+		property.PropNameEastAsianWidth,
+		property.PropNameGraphemeClusterBreak,
+		property.PropNameWordBreak,
+		property.PropNameSentenceBreak,
+		property.PropNameIndicConjunctBreak,
+	)
+	return names
+}()
+
+// ResolvePropertyName returns the long name of a property. The name may be any alias PropertyAliases.txt defines,
+// and it is matched loosely following UAX44-LM3.
+func (u *UCD) ResolvePropertyName(name string) (property.PropertyName, error) {
+	if long, ok := u.Unification.PropertyNames[property.NewPropertyName(name)]; ok {
+		return long, nil
+	}
+
+	// Properties newer than PropertyAliases.txt are known only to the lookup functions and extraPropertyAliases.
+	key := property.NormalizeSymbol(name)
+	for alias, long := range u.Unification.PropertyNames {
+		if property.NormalizeSymbol(alias.String()) == key {
+			return long, nil
+		}
+	}
+	for alias, long := range extraPropertyAliases {
+		if property.NormalizeSymbol(alias.String()) == key {
+			return long, nil
+		}
+	}
+	for n := range propertyLookups {
+		if property.NormalizeSymbol(n.String()) == key {
+			return n, nil
+		}
+	}
+	return "", fmt.Errorf("unknown property: %v", name)
+}
+
+// extraPropertyAliases holds the aliases of the properties PropertyAliases.txt of UnicodeVersion doesn't define.
+var extraPropertyAliases = map[property.PropertyName]property.PropertyName{
+	"InCB": property.PropNameIndicConjunctBreak,
+}
+
+// Property returns the value of a property of a code point. The name may be any alias of the property. Property
+// returns an error when the property is unknown or its values are not loaded.
+func (u *UCD) Property(c rune, name string) (property.PropertyValue, error) {
+	long, err := u.ResolvePropertyName(name)
+	if err != nil {
+		return nil, err
+	}
+	lookup, ok := propertyLookups[long]
+	if !ok {
+		return nil, fmt.Errorf("property not loaded: %v", long)
+	}
+	return lookup(u, c), nil
+}
+
+// PropertyLoaded reports whether Property can look up a property. The name must be a long name.
+func (u *UCD) PropertyLoaded(name property.PropertyName) bool {
+	_, ok := propertyLookups[name]
+	return ok
+}
+
+// PropertyValueRanges returns the code point ranges of each value of a property. The keys are the values Property
+// returns in their string forms. The name may be any alias of the property. For General_Category, the ranges of the
+// groups such as L are included too.
+func (u *UCD) PropertyValueRanges(name string) (map[string][]*property.CodePointRange, error) {
+	long, err := u.ResolvePropertyName(name)
+	if err != nil {
+		return nil, err
+	}
+	lookup, ok := propertyLookups[long]
+	if !ok {
+		return nil, fmt.Errorf("property not loaded: %v", long)
+	}
+
+	ranges := map[string][]*property.CodePointRange{}
+	var last string
+	for c := rune(0); c <= unicode.MaxRune; c++ {
+		v := lookup(u, c).String()
+		cps := ranges[v]
+		if c > 0 && v == last {
+			cps[len(cps)-1][1] = c
+			continue
+		}
+		ranges[v] = append(cps, property.NewCodePointRange(c, c))
+		last = v
+	}
+	if long == property.PropNameGeneralCategory {
+		addGCGroupRanges(ranges)
+	}
+	return ranges, nil
+}
+
+// CodePointRanges returns the code point ranges having a value of a property. Both the property and the value may be
+// any of their aliases, and they are matched loosely following UAX44-LM3.
+func (u *UCD) CodePointRanges(name, value string) ([]*property.CodePointRange, error) {
+	long, err := u.ResolvePropertyName(name)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := u.PropertyValueRanges(long.String())
+	if err != nil {
+		return nil, err
+	}
+
+	sym := property.NormalizeSymbol(value)
+	syms := []property.PropertyValueSymbol{sym}
+	known := false
+	for _, a := range u.PropertyValueAliases.Aliases[u.propertyAbb(long)] {
+		if a.Abb == sym || a.Long == sym || containsSymbol(a.Others, sym) {
+			syms = append([]property.PropertyValueSymbol{a.Abb, a.Long}, a.Others...)
+			known = true
+			break
+		}
+	}
+
+	var cps []*property.CodePointRange
+	for v, rs := range ranges {
+		if containsSymbol(syms, property.NormalizeSymbol(v)) {
+			cps = append(cps, rs...)
+			known = true
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown value of %v: %v", long, value)
+	}
+	return mergeCodePointRanges(cps), nil
+}
+
+// propertyAbb returns the abbreviated name of a property. PropertyValueAliases.txt identifies properties by their
+// abbreviated names.
+func (u *UCD) propertyAbb(long property.PropertyName) property.PropertyName {
+	if a := u.PropertyAliases.LookupAlias(long); a != nil {
+		return a.Abb
+	}
+	for abb, l := range extraPropertyAliases {
+		if l == long {
+			return abb
+		}
+	}
+	return long
+}
+
+func containsSymbol(syms []property.PropertyValueSymbol, sym property.PropertyValueSymbol) bool {
+	for _, s := range syms {
+		if s == sym {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeCodePointRanges sorts code point ranges and merges the overlapping or adjacent ones.
+func mergeCodePointRanges(cps []*property.CodePointRange) []*property.CodePointRange {
+	sorted := make([]*property.CodePointRange, len(cps))
+	copy(sorted, cps)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i][0] < sorted[j][0]
+	})
+
+	var merged []*property.CodePointRange
+	for _, cp := range sorted {
+		from, to := cp.Range()
+		if n := len(merged); n > 0 && from <= merged[n-1][1]+1 {
+			if to > merged[n-1][1] {
+				merged[n-1][1] = to
+			}
+			continue
+		}
+		merged = append(merged, property.NewCodePointRange(from, to))
+	}
+	return merged
 }
 
+// AnalizeCodePoint looks up the properties DefaultPropertyNames lists.
 func (u *UCD) AnalizeCodePoint(c rune) *PropertySet {
-	gc := u.lookupGeneralCategory(c)
-	return &PropertySet{
-		CP: c,
-		Properties: map[property.PropertyName]property.PropertyValue{
-			property.PropNameName:            u.lookupName(c),
-			property.PropNameNameAlias:       u.lookupNameAlias(c),
-			property.PropNameGeneralCategory: gc,
-			property.PropNameAlphabetic:      u.isAlphabetic(c),
-			property.PropNameUppercase:       u.isUppercase(c),
-			property.PropNameLowercase:       u.isLowercase(c),
-			property.PropNameIDStart:         u.isIDStart(c),
-			property.PropNameIDContinue:      u.isIDContinue(c),
-			property.PropNameXIDStart:        u.isXIDStart(c),
-			property.PropNameXIDContinue:     u.isXIDContinue(c),
-			property.PropNameWhiteSpace:      u.isWhiteSpace(c),
-		},
-		GeneralCategoryGroups: lookupGCGroups(gc),
+	p, _ := u.AnalizeCodePointProperties(c, DefaultPropertyNames)
+	return p
+}
+
+// AnalizeCodePointProperties looks up properties of a code point. The names must be long names.
+func (u *UCD) AnalizeCodePointProperties(c rune, names []property.PropertyName) (*PropertySet, error) {
+	props := make(map[property.PropertyName]property.PropertyValue, len(names))
+	for _, name := range names {
+		lookup, ok := propertyLookups[name]
+		if !ok {
+			return nil, fmt.Errorf("property not loaded: %v", name)
+		}
+		props[name] = lookup(u, c)
 	}
+	sc := u.lookupScript(c)
+	blk := u.lookupBlock(c)
+	return &PropertySet{
+		CP:                    c,
+		Properties:            props,
+		GeneralCategoryGroups: lookupGCGroups(u.lookupGeneralCategory(c)),
+		ScriptLongName:        sc.Long,
+		BlockLongName:         blk.Long,
+	}, nil
 }
 
 // 4.8 Table 4-8. Name Derivation Rule Prefix Strings in [Unicode].
@@ -98,106 +458,307 @@ func (u *UCD) lookupName(c rune) property.PropertyName {
 			}
 		}
 	}
-	for na, cp := range u.UnicodeData.Name {
-		if cp.Contain(c) {
-			return na
-		}
+	idx := u.index()
+	if na, ok := idx.names[c]; ok {
+		return na
+	}
+	if na, ok := idx.nameRanges.lookupValue(c); ok {
+		return property.NewPropertyName(na)
 	}
 	return property.NewPropertyName("")
 }
 
 func (u *UCD) lookupNameAlias(c rune) property.PropertyNameList {
-	for _, e := range u.NameAliases.Entries {
-		if e.CP == c {
-			as := make([]property.PropertyName, len(e.Aliases))
-			for i, alias := range e.Aliases {
-				as[i] = alias
-			}
-			return property.NewPropertyNameList(as)
-		}
+	e, ok := u.index().nameAliases[c]
+	if !ok {
+		return nil
+	}
+	as := make([]property.PropertyName, len(e.Aliases))
+	for i, alias := range e.Aliases {
+		as[i] = alias
 	}
-	return nil
+	return property.NewPropertyNameList(as)
 }
 
 func (u *UCD) lookupGeneralCategory(c rune) property.PropertyValueSymbol {
+	if gc, ok := u.index().generalCategory.lookupValue(c); ok {
+		return property.PropertyValueSymbol(gc)
+	}
+	return u.PropertyValueAliases.DefaultValues[property.PropNameGeneralCategory].Value
+}
+
+// lookupScript returns aliases of the Script property value of a code point. Scripts.txt lists the long names of
+// the values, so we complement the abbreviated names using PropertyValueAliases.txt.
+func (u *UCD) lookupScript(c rune) *property.PropertyValueAliase {
+	sc := u.PropertyValueAliases.DefaultValues[property.PropNameScript].Value
+	if v, ok := u.index().script.lookupValue(c); ok {
+		sc = property.PropertyValueSymbol(v)
+	}
+	return u.LookupPropertyValueAliase(property.PropNameScript, sc)
+}
+
+// lookupScriptExtensions returns the Script_Extensions property value of a code point. `sc` is the Script property
+// value of the same code point.
+func (u *UCD) lookupScriptExtensions(c rune, sc *property.PropertyValueAliase) property.PropertyValueSymbolList {
+	if e := u.index().lookupScriptExtensions(c); e != nil {
+		scx := make([]property.PropertyValueSymbol, len(e.Scripts))
+		for i, v := range e.Scripts {
+			scx[i] = u.LookupPropertyValueAliase(property.PropNameScript, v).Abb
+		}
+		return property.NewPropertyValueSymbolList(scx)
+	}
+
+	// Section 5.7.3 Script_Extensions in [UAX44] and section 2.11 Script_Extensions Property in [UAX24]:
+	// > The Script_Extensions property value for a code point not explicitly listed in ScriptExtensions.txt is
+	// > the value of the Script property of that code point.
+	return property.NewPropertyValueSymbolList([]property.PropertyValueSymbol{sc.Abb})
+}
+
+// lookupBlock returns aliases of the Block property value of a code point.
+func (u *UCD) lookupBlock(c rune) *property.PropertyValueAliase {
+	blk := u.PropertyValueAliases.DefaultValues[property.PropNameBlock].Value
+	if v, ok := u.index().block.lookupValue(c); ok {
+		blk = property.PropertyValueSymbol(v)
+	}
+	return u.LookupPropertyValueAliase(property.PropNameBlock, blk)
+}
+
+// lookupLineBreak returns the Line_Break property value of a code point. Code points not listed in LineBreak.txt
+// have the default values its `@missing` lines specify.
+func (u *UCD) lookupLineBreak(c rune) property.PropertyValueSymbol {
+	if v, ok := u.index().lineBreak.lookupValue(c); ok {
+		return property.PropertyValueSymbol(v)
+	}
+	if v, ok := property.LookupDefaultValue(u.LineBreak.Defaults, c); ok {
+		return v
+	}
+	return u.PropertyValueAliases.DefaultValues[property.PropNameLineBreak].Value
+}
+
+// lookupEastAsianWidth returns the East_Asian_Width property value of a code point. Code points not listed in
+// EastAsianWidth.txt have the default values its `@missing` lines specify.
+func (u *UCD) lookupEastAsianWidth(c rune) property.PropertyValueSymbol {
+	if v, ok := u.index().eastAsianWidth.lookupValue(c); ok {
+		return property.PropertyValueSymbol(v)
+	}
+	if v, ok := property.LookupDefaultValue(u.EastAsianWidth.Defaults, c); ok {
+		return v
+	}
+	return u.PropertyValueAliases.DefaultValues[property.PropNameEastAsianWidth].Value
+}
+
+// lookupIndicConjunctBreak returns the Indic_Conjunct_Break property. Code points not listed in
+// DerivedCoreProperties.txt have the value None.
+func (u *UCD) lookupIndicConjunctBreak(c rune) property.PropertyValueSymbol {
+	if v, ok := u.index().indicConjunctBreak.lookupValue(c); ok {
+		return property.PropertyValueSymbol(v)
+	}
+	return property.NewSymbolPropertyValue("none")
+}
+
+// lookupBreakProperty returns the value of a property for text segmentation. Code points not listed in the data
+// file have the value Other.
+func lookupBreakProperty(idx *rangeIndex, c rune) property.PropertyValueSymbol {
+	if v, ok := idx.lookupValue(c); ok {
+		return property.PropertyValueSymbol(v)
+	}
+	return property.NewSymbolPropertyValue("other")
+}
+
+// CountAssignedCodePoints returns the number of assigned code points in a range. A code point is assigned when its
+// General_Category is not Unassigned (Cn).
+func (u *UCD) CountAssignedCodePoints(r *property.CodePointRange) int {
+	from, to := r.Range()
+	n := 0
 	for gc, cps := range u.UnicodeData.GeneralCategory {
+		if gc == "cn" {
+			continue
+		}
 		for _, cp := range cps {
-			if cp.Contain(c) {
-				return gc
+			f, t := cp.Range()
+			if f < from {
+				f = from
+			}
+			if t > to {
+				t = to
+			}
+			if f <= t {
+				n += int(t-f) + 1
 			}
 		}
 	}
-	return u.PropertyValueAliases.DefaultValues[property.PropNameGeneralCategory].Value
+	return n
 }
 
-func (u *UCD) isAlphabetic(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameAlphabetic] {
-		if cp.Contain(c) {
-			return property.BinaryYes
+// LookupPropertyValueAliase returns aliases of a property value. When PropertyValueAliases.txt doesn't contain the
+// value, this function returns the value itself as the aliases.
+func (u *UCD) LookupPropertyValueAliase(propName property.PropertyName, v property.PropertyValueSymbol) *property.PropertyValueAliase {
+	if propAlias := u.PropertyAliases.LookupAlias(propName); propAlias != nil {
+		if alias := u.PropertyValueAliases.LookupAlias(propAlias.Abb, v); alias != nil {
+			return alias
 		}
 	}
-	return property.BinaryNo
+	return &property.PropertyValueAliase{
+		Abb:  v,
+		Long: v,
+	}
 }
 
-func (u *UCD) isLowercase(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameLowercase] {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+func (u *UCD) lookupCanonicalCombiningClass(c rune) property.PropertyValueSymbol {
+	if ccc, ok := u.index().canonicalCombiningClass.lookupValue(c); ok {
+		return property.PropertyValueSymbol(ccc)
 	}
-	return property.BinaryNo
+	// Section 5.7.4 Canonical_Combining_Class in [UAX44]: code points not listed in UnicodeData.txt take the value 0.
+	return "0"
 }
 
-func (u *UCD) isUppercase(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameUppercase] {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+func (u *UCD) lookupBidiClass(c rune) property.PropertyValueSymbol {
+	if bc, ok := u.index().bidiClass.lookupValue(c); ok {
+		return property.PropertyValueSymbol(bc)
 	}
-	return property.BinaryNo
+	return u.PropertyValueAliases.DefaultValues[property.PropNameBidiClass].Value
 }
 
-func (u *UCD) isIDStart(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameIDStart] {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+// lookupBidiMirroringGlyph returns the Bidi_Mirroring_Glyph property value of a code point. The value of a code
+// point not listed in BidiMirroring.txt is <none>, which is represented as an empty sequence.
+func (u *UCD) lookupBidiMirroringGlyph(c rune) property.PropertyValueCodePoints {
+	if m, ok := u.BidiMirroring.MirroringGlyph[c]; ok {
+		return property.NewPropertyValueCodePoints([]rune{m})
 	}
-	return property.BinaryNo
+	return property.NewPropertyValueCodePoints(nil)
 }
 
-func (u *UCD) isIDContinue(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameIDContinue] {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+// lookupBidiPairedBracket returns the Bidi_Paired_Bracket property value of a code point. The value of a code point
+// not listed in BidiBrackets.txt is <none>, which is represented as an empty sequence.
+func (u *UCD) lookupBidiPairedBracket(c rune) property.PropertyValueCodePoints {
+	if b, ok := u.BidiBrackets.PairedBracket[c]; ok {
+		return property.NewPropertyValueCodePoints([]rune{b})
 	}
-	return property.BinaryNo
+	return property.NewPropertyValueCodePoints(nil)
 }
 
-func (u *UCD) isXIDStart(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameXIDStart] {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+func (u *UCD) lookupBidiPairedBracketType(c rune) property.PropertyValueSymbol {
+	if t, ok := u.BidiBrackets.PairedBracketType[c]; ok {
+		return t
 	}
-	return property.BinaryNo
+	return u.PropertyValueAliases.DefaultValues[property.PropNameBidiPairedBracketType].Value
 }
 
-func (u *UCD) isXIDContinue(c rune) property.PropertyValueBinary {
-	for _, cp := range u.DerivedCoreProperties.Entries[property.PropNameXIDContinue] {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+// lookupDecomposition returns the Decomposition_Type and the Decomposition_Mapping property values of a code
+// point. The Decomposition_Mapping of a code point that has no decomposition is the code point itself.
+func (u *UCD) lookupDecomposition(c rune) (property.PropertyValueSymbol, property.PropertyValueCodePoints) {
+	// The decompositions of Hangul syllables are not listed in UnicodeData.txt because they are derived
+	// algorithmically. See section 3.12 Conjoining Jamo Behavior in [Unicode].
+	if isHangulSyllable(c) {
+		dt := u.LookupPropertyValueAliase(property.PropNameDecompositionType, "canonical")
+		return dt.Abb, property.NewPropertyValueCodePoints(decomposeHangulSyllable(c))
+	}
+	d, ok := u.UnicodeData.Decomposition[c]
+	if !ok {
+		dt := u.LookupPropertyValueAliase(property.PropNameDecompositionType, "none")
+		return dt.Abb, property.NewPropertyValueCodePoints([]rune{c})
+	}
+	dt := u.LookupPropertyValueAliase(property.PropNameDecompositionType, d.Type)
+	return dt.Abb, property.NewPropertyValueCodePoints(d.Mapping)
+}
+
+// lookupNumeric returns the Numeric_Type and the Numeric_Value property values of a code point.
+//
+// Note that UnicodeData.txt doesn't contain the numeric values of CJK ideographs. They are defined in the Unihan
+// database.
+func (u *UCD) lookupNumeric(c rune) (property.PropertyValueSymbol, property.PropertyValueNumeric) {
+	n, ok := u.UnicodeData.Numeric[c]
+	if !ok {
+		return u.LookupPropertyValueAliase(property.PropNameNumericType, "none").Abb, "NaN"
+	}
+	return u.LookupPropertyValueAliase(property.PropNameNumericType, n.Type).Abb, n.Value
+}
+
+func (u *UCD) isBidiMirrored(c rune) property.PropertyValueBinary {
+	if u.index().bidiMirrored.contain(c) {
+		return property.BinaryYes
 	}
 	return property.BinaryNo
 }
 
-func (u *UCD) isWhiteSpace(c rune) property.PropertyValueBinary {
-	for _, cp := range u.PropList.WhiteSpace {
-		if cp.Contain(c) {
-			return property.BinaryYes
-		}
+func (u *UCD) lookupUnicode1Name(c rune) property.PropertyName {
+	return u.UnicodeData.Unicode1Name[c]
+}
+
+// The simple case mappings of a code point not listed in UnicodeData.txt are the code point itself.
+// See section 5.6 Case and Case Mapping in [UAX44].
+
+func (u *UCD) lookupSimpleUppercaseMapping(c rune) property.PropertyValueCodePoints {
+	if m, ok := u.UnicodeData.SimpleUppercaseMapping[c]; ok {
+		return property.NewPropertyValueCodePoints([]rune{m})
+	}
+	return property.NewPropertyValueCodePoints([]rune{c})
+}
+
+func (u *UCD) lookupSimpleLowercaseMapping(c rune) property.PropertyValueCodePoints {
+	if m, ok := u.UnicodeData.SimpleLowercaseMapping[c]; ok {
+		return property.NewPropertyValueCodePoints([]rune{m})
+	}
+	return property.NewPropertyValueCodePoints([]rune{c})
+}
+
+func (u *UCD) lookupSimpleTitlecaseMapping(c rune) property.PropertyValueCodePoints {
+	if m, ok := u.UnicodeData.SimpleTitlecaseMapping[c]; ok {
+		return property.NewPropertyValueCodePoints([]rune{m})
+	}
+	// Section 4.2.8 UnicodeData.txt in [UAX44]:
+	// > If this field is null, then the Simple_Titlecase_Mapping is the same as the Simple_Uppercase_Mapping for
+	// > this character.
+	return u.lookupSimpleUppercaseMapping(c)
+}
+
+// lookupSpecialCasing returns the unconditional entry of SpecialCasing.txt for a code point. Conditional entries are
+// not the values of the properties because they depend on the contexts.
+func (u *UCD) lookupSpecialCasing(c rune) *property.SpecialCasingEntry {
+	return u.index().specialCasing[c]
+}
+
+func (u *UCD) lookupUppercaseMapping(c rune) property.PropertyValueCodePoints {
+	if e := u.lookupSpecialCasing(c); e != nil {
+		return property.NewPropertyValueCodePoints(e.Upper)
+	}
+	return u.lookupSimpleUppercaseMapping(c)
+}
+
+func (u *UCD) lookupLowercaseMapping(c rune) property.PropertyValueCodePoints {
+	if e := u.lookupSpecialCasing(c); e != nil {
+		return property.NewPropertyValueCodePoints(e.Lower)
+	}
+	return u.lookupSimpleLowercaseMapping(c)
+}
+
+func (u *UCD) lookupTitlecaseMapping(c rune) property.PropertyValueCodePoints {
+	if e := u.lookupSpecialCasing(c); e != nil {
+		return property.NewPropertyValueCodePoints(e.Title)
+	}
+	return u.lookupSimpleTitlecaseMapping(c)
+}
+
+// lookupSimpleCaseFolding returns the Simple_Case_Folding property value of a code point. Code points not listed in
+// CaseFolding.txt fold to themselves.
+func (u *UCD) lookupSimpleCaseFolding(c rune) property.PropertyValueCodePoints {
+	if m, ok := u.CaseFolding.Simple[c]; ok {
+		return property.NewPropertyValueCodePoints([]rune{m})
+	}
+	return property.NewPropertyValueCodePoints([]rune{c})
+}
+
+func (u *UCD) lookupCaseFolding(c rune) property.PropertyValueCodePoints {
+	if m, ok := u.CaseFolding.Full[c]; ok {
+		return property.NewPropertyValueCodePoints(m)
+	}
+	return property.NewPropertyValueCodePoints([]rune{c})
+}
+
+// lookupBinaryProperty returns the value of a binary property of a code point.
+func (u *UCD) lookupBinaryProperty(name property.PropertyName, c rune) property.PropertyValueBinary {
+	if idx, ok := u.index().binary[name]; ok && idx.contain(c) {
+		return property.BinaryYes
 	}
 	return property.BinaryNo
 }
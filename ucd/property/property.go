@@ -43,19 +43,170 @@ func (n PropertyName) String() string {
 }
 
 const (
-	PropNameName            PropertyName = "Name"
-	PropNameNameAlias       PropertyName = "Name_Alias"
-	PropNameGeneralCategory PropertyName = "General_Category"
-	PropNameWhiteSpace      PropertyName = "White_Space"
-	PropNameAlphabetic      PropertyName = "Alphabetic"
-	PropNameLowercase       PropertyName = "Lowercase"
-	PropNameUppercase       PropertyName = "Uppercase"
-	PropNameIDStart         PropertyName = "ID_Start"
-	PropNameIDContinue      PropertyName = "ID_Continue"
-	PropNameXIDStart        PropertyName = "ID_XStart"
-	PropNameXIDContinue     PropertyName = "ID_XContinue"
+	PropNameName             PropertyName = "Name"
+	PropNameNameAlias        PropertyName = "Name_Alias"
+	PropNameGeneralCategory  PropertyName = "General_Category"
+	PropNameWhiteSpace       PropertyName = "White_Space"
+	PropNameAlphabetic       PropertyName = "Alphabetic"
+	PropNameLowercase        PropertyName = "Lowercase"
+	PropNameUppercase        PropertyName = "Uppercase"
+	PropNameIDStart          PropertyName = "ID_Start"
+	PropNameIDContinue       PropertyName = "ID_Continue"
+	PropNameXIDStart         PropertyName = "XID_Start"
+	PropNameXIDContinue      PropertyName = "XID_Continue"
+	PropNameScript           PropertyName = "Script"
+	PropNameScriptExtensions PropertyName = "Script_Extensions"
+	PropNameBlock            PropertyName = "Block"
+
+	// The following properties are defined in UnicodeData.txt.
+	PropNameCanonicalCombiningClass PropertyName = "Canonical_Combining_Class"
+	PropNameBidiClass               PropertyName = "Bidi_Class"
+	PropNameDecompositionType       PropertyName = "Decomposition_Type"
+	PropNameDecompositionMapping    PropertyName = "Decomposition_Mapping"
+	PropNameNumericType             PropertyName = "Numeric_Type"
+	PropNameNumericValue            PropertyName = "Numeric_Value"
+	PropNameBidiMirrored            PropertyName = "Bidi_Mirrored"
+	PropNameUnicode1Name            PropertyName = "Unicode_1_Name"
+	PropNameISOComment              PropertyName = "ISO_Comment"
+	PropNameSimpleUppercaseMapping  PropertyName = "Simple_Uppercase_Mapping"
+	PropNameSimpleLowercaseMapping  PropertyName = "Simple_Lowercase_Mapping"
+	PropNameSimpleTitlecaseMapping  PropertyName = "Simple_Titlecase_Mapping"
+
+	PropNameFullCompositionExclusion PropertyName = "Full_Composition_Exclusion"
+
+	PropNameGraphemeClusterBreak PropertyName = "Grapheme_Cluster_Break"
+	PropNameExtendedPictographic PropertyName = "Extended_Pictographic"
+	PropNameWordBreak            PropertyName = "Word_Break"
+	PropNameSentenceBreak        PropertyName = "Sentence_Break"
+	PropNameLineBreak            PropertyName = "Line_Break"
+	PropNameEastAsianWidth       PropertyName = "East_Asian_Width"
+
+	PropNameBidiMirroringGlyph    PropertyName = "Bidi_Mirroring_Glyph"
+	PropNameBidiPairedBracket     PropertyName = "Bidi_Paired_Bracket"
+	PropNameBidiPairedBracketType PropertyName = "Bidi_Paired_Bracket_Type"
+
+	PropNameUppercaseMapping  PropertyName = "Uppercase_Mapping"
+	PropNameLowercaseMapping  PropertyName = "Lowercase_Mapping"
+	PropNameTitlecaseMapping  PropertyName = "Titlecase_Mapping"
+	PropNameCaseFolding       PropertyName = "Case_Folding"
+	PropNameSimpleCaseFolding PropertyName = "Simple_Case_Folding"
+	PropNameCased             PropertyName = "Cased"
+	PropNameCaseIgnorable     PropertyName = "Case_Ignorable"
 )
 
+// The following properties are defined in DerivedCoreProperties.txt in addition to the ones above.
+const (
+	PropNameMath                      PropertyName = "Math"
+	PropNameChangesWhenLowercased     PropertyName = "Changes_When_Lowercased"
+	PropNameChangesWhenUppercased     PropertyName = "Changes_When_Uppercased"
+	PropNameChangesWhenTitlecased     PropertyName = "Changes_When_Titlecased"
+	PropNameChangesWhenCasefolded     PropertyName = "Changes_When_Casefolded"
+	PropNameChangesWhenCasemapped     PropertyName = "Changes_When_Casemapped"
+	PropNameDefaultIgnorableCodePoint PropertyName = "Default_Ignorable_Code_Point"
+	PropNameGraphemeExtend            PropertyName = "Grapheme_Extend"
+	PropNameGraphemeBase              PropertyName = "Grapheme_Base"
+	PropNameGraphemeLink              PropertyName = "Grapheme_Link"
+	PropNameIndicConjunctBreak        PropertyName = "Indic_Conjunct_Break"
+)
+
+// DerivedCorePropertyNames lists the binary properties DerivedCoreProperties.txt defines in the order of the file.
+var DerivedCorePropertyNames = []PropertyName{
+	PropNameMath,
+	PropNameAlphabetic,
+	PropNameLowercase,
+	PropNameUppercase,
+	PropNameCased,
+	PropNameCaseIgnorable,
+	PropNameChangesWhenLowercased,
+	PropNameChangesWhenUppercased,
+	PropNameChangesWhenTitlecased,
+	PropNameChangesWhenCasefolded,
+	PropNameChangesWhenCasemapped,
+	PropNameIDStart,
+	PropNameIDContinue,
+	PropNameXIDStart,
+	PropNameXIDContinue,
+	PropNameDefaultIgnorableCodePoint,
+	PropNameGraphemeExtend,
+	PropNameGraphemeBase,
+	PropNameGraphemeLink,
+}
+
+// The following properties are defined in PropList.txt in addition to White_Space.
+const (
+	PropNameBidiControl                    PropertyName = "Bidi_Control"
+	PropNameJoinControl                    PropertyName = "Join_Control"
+	PropNameDash                           PropertyName = "Dash"
+	PropNameHyphen                         PropertyName = "Hyphen"
+	PropNameQuotationMark                  PropertyName = "Quotation_Mark"
+	PropNameTerminalPunctuation            PropertyName = "Terminal_Punctuation"
+	PropNameOtherMath                      PropertyName = "Other_Math"
+	PropNameHexDigit                       PropertyName = "Hex_Digit"
+	PropNameASCIIHexDigit                  PropertyName = "ASCII_Hex_Digit"
+	PropNameOtherAlphabetic                PropertyName = "Other_Alphabetic"
+	PropNameIdeographic                    PropertyName = "Ideographic"
+	PropNameDiacritic                      PropertyName = "Diacritic"
+	PropNameExtender                       PropertyName = "Extender"
+	PropNameOtherLowercase                 PropertyName = "Other_Lowercase"
+	PropNameOtherUppercase                 PropertyName = "Other_Uppercase"
+	PropNameNoncharacterCodePoint          PropertyName = "Noncharacter_Code_Point"
+	PropNameOtherGraphemeExtend            PropertyName = "Other_Grapheme_Extend"
+	PropNameIDSBinaryOperator              PropertyName = "IDS_Binary_Operator"
+	PropNameIDSTrinaryOperator             PropertyName = "IDS_Trinary_Operator"
+	PropNameRadical                        PropertyName = "Radical"
+	PropNameUnifiedIdeograph               PropertyName = "Unified_Ideograph"
+	PropNameOtherDefaultIgnorableCodePoint PropertyName = "Other_Default_Ignorable_Code_Point"
+	PropNameDeprecated                     PropertyName = "Deprecated"
+	PropNameSoftDotted                     PropertyName = "Soft_Dotted"
+	PropNameLogicalOrderException          PropertyName = "Logical_Order_Exception"
+	PropNameOtherIDStart                   PropertyName = "Other_ID_Start"
+	PropNameOtherIDContinue                PropertyName = "Other_ID_Continue"
+	PropNameSentenceTerminal               PropertyName = "Sentence_Terminal"
+	PropNameVariationSelector              PropertyName = "Variation_Selector"
+	PropNamePatternWhiteSpace              PropertyName = "Pattern_White_Space"
+	PropNamePatternSyntax                  PropertyName = "Pattern_Syntax"
+	PropNamePrependedConcatenationMark     PropertyName = "Prepended_Concatenation_Mark"
+	PropNameRegionalIndicator              PropertyName = "Regional_Indicator"
+)
+
+// PropListPropertyNames lists the properties PropList.txt defines in the order of the file.
+var PropListPropertyNames = []PropertyName{
+	PropNameWhiteSpace,
+	PropNameBidiControl,
+	PropNameJoinControl,
+	PropNameDash,
+	PropNameHyphen,
+	PropNameQuotationMark,
+	PropNameTerminalPunctuation,
+	PropNameOtherMath,
+	PropNameHexDigit,
+	PropNameASCIIHexDigit,
+	PropNameOtherAlphabetic,
+	PropNameIdeographic,
+	PropNameDiacritic,
+	PropNameExtender,
+	PropNameOtherLowercase,
+	PropNameOtherUppercase,
+	PropNameNoncharacterCodePoint,
+	PropNameOtherGraphemeExtend,
+	PropNameIDSBinaryOperator,
+	PropNameIDSTrinaryOperator,
+	PropNameRadical,
+	PropNameUnifiedIdeograph,
+	PropNameOtherDefaultIgnorableCodePoint,
+	PropNameDeprecated,
+	PropNameSoftDotted,
+	PropNameLogicalOrderException,
+	PropNameOtherIDStart,
+	PropNameOtherIDContinue,
+	PropNameSentenceTerminal,
+	PropNameVariationSelector,
+	PropNamePatternWhiteSpace,
+	PropNamePatternSyntax,
+	PropNamePrependedConcatenationMark,
+	PropNameRegionalIndicator,
+}
+
 type PropertyNameList []PropertyName
 
 func NewPropertyNameList(v []PropertyName) PropertyNameList {
@@ -81,10 +232,77 @@ func NewSymbolPropertyValue(v string) PropertyValueSymbol {
 	return PropertyValueSymbol(v)
 }
 
+var symValReplacer = strings.NewReplacer("_", "", "-", "", "\x20", "")
+
+// NormalizeSymbol returns a normalized symbolic value or property name, so that equivalent ones compare equal.
+//
+// The normalization algorithm follows UAX44-LM3 defined section 5.9.3 Matching Symbolic Values in [UAX44].
+func NormalizeSymbol(s string) PropertyValueSymbol {
+	sym := strings.ToLower(symValReplacer.Replace(s))
+	if sym == "is" {
+		return NewSymbolPropertyValue(sym)
+	}
+	return NewSymbolPropertyValue(strings.TrimPrefix(sym, "is"))
+}
+
 func (v PropertyValueSymbol) String() string {
 	return string(v)
 }
 
+type PropertyValueSymbolList []PropertyValueSymbol
+
+func NewPropertyValueSymbolList(v []PropertyValueSymbol) PropertyValueSymbolList {
+	return PropertyValueSymbolList(v)
+}
+
+func (v PropertyValueSymbolList) String() string {
+	if len(v) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprint(&b, v[0].String())
+	for _, sym := range v[1:] {
+		fmt.Fprintf(&b, ", %v", sym)
+	}
+	return b.String()
+}
+
+// PropertyValueCodePoints represents a value of string properties whose values are sequences of code points,
+// such as Decomposition_Mapping and Simple_Uppercase_Mapping.
+type PropertyValueCodePoints []rune
+
+func NewPropertyValueCodePoints(cps []rune) PropertyValueCodePoints {
+	return PropertyValueCodePoints(cps)
+}
+
+func (v PropertyValueCodePoints) String() string {
+	if len(v) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "U+%04X", v[0])
+	for _, c := range v[1:] {
+		fmt.Fprintf(&b, " U+%04X", c)
+	}
+	return b.String()
+}
+
+// PropertyValueNumeric represents a value of the Numeric_Value property. The value is a decimal integer or a
+// rational number such as `1/2`.
+type PropertyValueNumeric string
+
+func (v PropertyValueNumeric) String() string {
+	return string(v)
+}
+
+type PropertyValueString string
+
+func (v PropertyValueString) String() string {
+	return string(v)
+}
+
 type PropertyValueBinary bool
 
 const (
@@ -111,32 +329,101 @@ func NewProperty(name PropertyName, value PropertyValue) *Property {
 	}
 }
 
+// Decomposition represents the Decomposition_Type and the Decomposition_Mapping properties of a code point.
+type Decomposition struct {
+	Type    PropertyValueSymbol `json:"type"`
+	Mapping []rune              `json:"mapping"`
+}
+
+// Numeric represents the Numeric_Type and the Numeric_Value properties of a code point.
+type Numeric struct {
+	Type  PropertyValueSymbol  `json:"type"`
+	Value PropertyValueNumeric `json:"value"`
+}
+
+// UnicodeData represents the properties UnicodeData.txt defines.
+//
+// See section 4.2.8 UnicodeData.txt in [UAX44] for more details on each field.
 type UnicodeData struct {
-	Name            map[PropertyName]*CodePointRange          `json:"name"`
-	GeneralCategory map[PropertyValueSymbol][]*CodePointRange `json:"general_category"`
+	Name                    map[PropertyName]*CodePointRange          `json:"name"`
+	GeneralCategory         map[PropertyValueSymbol][]*CodePointRange `json:"general_category"`
+	CanonicalCombiningClass map[PropertyValueSymbol][]*CodePointRange `json:"canonical_combining_class"`
+	BidiClass               map[PropertyValueSymbol][]*CodePointRange `json:"bidi_class"`
+	Decomposition           map[rune]*Decomposition                   `json:"decomposition"`
+	Numeric                 map[rune]*Numeric                         `json:"numeric"`
+	BidiMirrored            []*CodePointRange                         `json:"bidi_mirrored"`
+	Unicode1Name            map[rune]PropertyName                     `json:"unicode_1_name"`
+	ISOComment              map[rune]PropertyValueString              `json:"iso_comment"`
+	SimpleUppercaseMapping  map[rune]rune                             `json:"simple_uppercase_mapping"`
+	SimpleLowercaseMapping  map[rune]rune                             `json:"simple_lowercase_mapping"`
+	SimpleTitlecaseMapping  map[rune]rune                             `json:"simple_titlecase_mapping"`
+}
+
+func NewUnicodeData() *UnicodeData {
+	return &UnicodeData{
+		Name:                    map[PropertyName]*CodePointRange{},
+		GeneralCategory:         map[PropertyValueSymbol][]*CodePointRange{},
+		CanonicalCombiningClass: map[PropertyValueSymbol][]*CodePointRange{},
+		BidiClass:               map[PropertyValueSymbol][]*CodePointRange{},
+		Decomposition:           map[rune]*Decomposition{},
+		Numeric:                 map[rune]*Numeric{},
+		Unicode1Name:            map[rune]PropertyName{},
+		ISOComment:              map[rune]PropertyValueString{},
+		SimpleUppercaseMapping:  map[rune]rune{},
+		SimpleLowercaseMapping:  map[rune]rune{},
+		SimpleTitlecaseMapping:  map[rune]rune{},
+	}
 }
 
+// Section 4.2.11 Empty Fields in [UAX44]:
+// > The data file UnicodeData.txt defines many property values in each record. When a field in a data line
+// > for a code point is empty, that indicates that the property takes the default value for that code point.
+//
+// Thus, the following methods ignore empty values.
+
 func (u *UnicodeData) AddGC(gc PropertyValueSymbol, cp *CodePointRange) {
-	// Section 4.2.11 Empty Fields in [UAX44]:
-	// > The data file UnicodeData.txt defines many property values in each record. When a field in a data line
-	// > for a code point is empty, that indicates that the property takes the default value for that code point.
 	if gc == "" {
 		return
 	}
+	u.GeneralCategory[gc] = appendCodePointRange(u.GeneralCategory[gc], cp)
+}
 
-	cps, ok := u.GeneralCategory[gc]
-	if ok {
-		from1, to1 := cp.Range()
-		i := len(cps) - 1
-		from2, to2 := cps[i].Range()
-		if from1-to2 == 1 {
-			cps[i] = NewCodePointRange(from2, to1)
-		} else {
-			u.GeneralCategory[gc] = append(cps, cp)
-		}
-	} else {
-		u.GeneralCategory[gc] = []*CodePointRange{cp}
+func (u *UnicodeData) AddCCC(ccc PropertyValueSymbol, cp *CodePointRange) {
+	if ccc == "" {
+		return
 	}
+	u.CanonicalCombiningClass[ccc] = appendCodePointRange(u.CanonicalCombiningClass[ccc], cp)
+}
+
+func (u *UnicodeData) AddBidiClass(bc PropertyValueSymbol, cp *CodePointRange) {
+	if bc == "" {
+		return
+	}
+	u.BidiClass[bc] = appendCodePointRange(u.BidiClass[bc], cp)
+}
+
+func (u *UnicodeData) AddBidiMirrored(mirrored PropertyValueBinary, cp *CodePointRange) {
+	if !mirrored {
+		return
+	}
+	u.BidiMirrored = appendCodePointRange(u.BidiMirrored, cp)
+}
+
+// appendCodePointRange appends a code point range to a list. When the range is adjacent to the last element of
+// the list, this function merges them. The caller must pass the ranges in ascending order.
+func appendCodePointRange(cps []*CodePointRange, cp *CodePointRange) []*CodePointRange {
+	if len(cps) == 0 {
+		return []*CodePointRange{cp}
+	}
+
+	from1, to1 := cp.Range()
+	i := len(cps) - 1
+	from2, to2 := cps[i].Range()
+	if from1-to2 == 1 {
+		cps[i] = NewCodePointRange(from2, to1)
+		return cps
+	}
+	return append(cps, cp)
 }
 
 type NameAliasesEntry struct {
@@ -148,20 +435,49 @@ type NameAliases struct {
 	Entries []*NameAliasesEntry `json:"entries"`
 }
 
+// DerivedCoreProperties represents the properties DerivedCoreProperties.txt defines. `Entries` holds the binary
+// properties, and `IndicConjunctBreak` holds the Indic_Conjunct_Break property, which is the only enumerated one.
+// Code points not listed in `IndicConjunctBreak` have the value None.
 type DerivedCoreProperties struct {
-	Entries map[PropertyName][]*CodePointRange `json:"entries"`
+	Entries            map[PropertyName][]*CodePointRange        `json:"entries"`
+	IndicConjunctBreak map[PropertyValueSymbol][]*CodePointRange `json:"indic_conjunct_break"`
+}
+
+// DerivedNormalizationProps represents the properties DerivedNormalizationProps.txt defines. `Entries` holds the
+// binary properties, and `QuickCheck` holds the quick check properties such as NFC_Quick_Check.
+type DerivedNormalizationProps struct {
+	Entries    map[PropertyName][]*CodePointRange                         `json:"entries"`
+	QuickCheck map[PropertyName]map[PropertyValueSymbol][]*CodePointRange `json:"quick_check"`
 }
 
+// PropertyAlias is a set of aliases of a property. `Type` is empty when PropertyAliases.txt doesn't group the
+// property under a heading of its type.
 type PropertyAlias struct {
 	Abb    PropertyName   `json:"abb"`
 	Long   PropertyName   `json:"long"`
 	Others []PropertyName `json:"others"`
+	Type   PropertyType   `json:"type,omitempty"`
 }
 
 type PropertyAliases struct {
 	Aliases []*PropertyAlias `json:"aliases"`
 }
 
+// LookupAlias returns a set of aliases containing a property name. The name may be any alias of a property.
+func (a *PropertyAliases) LookupAlias(name PropertyName) *PropertyAlias {
+	for _, alias := range a.Aliases {
+		if alias.Abb == name || alias.Long == name {
+			return alias
+		}
+		for _, o := range alias.Others {
+			if o == name {
+				return alias
+			}
+		}
+	}
+	return nil
+}
+
 // PropertyValueAliase represents a set of aliases for a property value.
 // `Abb` and `Long` are the preferred aliases.
 type PropertyValueAliase struct {
@@ -185,8 +501,130 @@ type PropertyValueAliases struct {
 	DefaultValues map[PropertyName]*DefaultValue          `json:"default_values"`
 }
 
+// LookupAlias returns a set of aliases containing a property value. `propAbb` is an abbreviated name of a property,
+// and `value` is a normalized symbolic value.
+func (a *PropertyValueAliases) LookupAlias(propAbb PropertyName, value PropertyValueSymbol) *PropertyValueAliase {
+	for _, alias := range a.Aliases[propAbb] {
+		if alias.Abb == value || alias.Long == value {
+			return alias
+		}
+		for _, o := range alias.Others {
+			if o == value {
+				return alias
+			}
+		}
+	}
+	return nil
+}
+
+// LookupDefaultValue returns a default value of a code point from a list of default values in the order the
+// `@missing` lines appear. When ranges of the default values overlap, a later one takes precedence. See section
+// 4.2.10 @missing Conventions in [UAX44].
+func LookupDefaultValue(defaults []*DefaultValue, c rune) (PropertyValueSymbol, bool) {
+	for i := len(defaults) - 1; i >= 0; i-- {
+		if defaults[i].CP.Contain(c) {
+			return defaults[i].Value, true
+		}
+	}
+	return "", false
+}
+
+type Scripts struct {
+	Entries map[PropertyValueSymbol][]*CodePointRange `json:"entries"`
+}
+
+type ScriptExtensionsEntry struct {
+	CP      *CodePointRange       `json:"cp"`
+	Scripts []PropertyValueSymbol `json:"scripts"`
+}
+
+// ScriptExtensions represents the Script_Extensions property. Each entry holds a set of abbreviated names of the
+// Script property values.
+type ScriptExtensions struct {
+	Entries []*ScriptExtensionsEntry `json:"entries"`
+}
+
+type BlocksEntry struct {
+	CP    *CodePointRange     `json:"cp"`
+	Block PropertyValueSymbol `json:"block"`
+}
+
+type Blocks struct {
+	Entries []*BlocksEntry `json:"entries"`
+}
+
+type GraphemeBreakProperty struct {
+	Entries map[PropertyValueSymbol][]*CodePointRange `json:"entries"`
+}
+
+// LineBreak represents the Line_Break property. `Defaults` holds the default values of code points not listed in
+// LineBreak.txt.
+type LineBreak struct {
+	Entries  map[PropertyValueSymbol][]*CodePointRange `json:"entries"`
+	Defaults []*DefaultValue                           `json:"defaults"`
+}
+
+// EastAsianWidth represents the East_Asian_Width property. `Defaults` holds the default values of code points not
+// listed in EastAsianWidth.txt.
+type EastAsianWidth struct {
+	Entries  map[PropertyValueSymbol][]*CodePointRange `json:"entries"`
+	Defaults []*DefaultValue                           `json:"defaults"`
+}
+
+// BidiBrackets represents the Bidi_Paired_Bracket and the Bidi_Paired_Bracket_Type properties. Code points not
+// listed in BidiBrackets.txt have no paired bracket, and their Bidi_Paired_Bracket_Type is None.
+type BidiBrackets struct {
+	PairedBracket     map[rune]rune                `json:"paired_bracket"`
+	PairedBracketType map[rune]PropertyValueSymbol `json:"paired_bracket_type"`
+}
+
+// BidiMirroring represents the Bidi_Mirroring_Glyph property. Code points not listed in BidiMirroring.txt have no
+// mirroring glyph.
+type BidiMirroring struct {
+	MirroringGlyph map[rune]rune `json:"mirroring_glyph"`
+}
+
+// CaseFolding represents the mappings CaseFolding.txt defines. The Simple_Case_Folding property consists of the
+// mappings whose status is C or S, and the Case_Folding property consists of the ones whose status is C or F.
+// `Turkic` holds the mappings whose status is T, which are used instead of the others for Turkic languages.
+type CaseFolding struct {
+	Simple map[rune]rune   `json:"simple"`
+	Full   map[rune][]rune `json:"full"`
+	Turkic map[rune]rune   `json:"turkic"`
+}
+
+// SpecialCasingEntry is a record of SpecialCasing.txt. `Conditions` holds language IDs such as `tr` and casing
+// contexts such as `Final_Sigma`, and the mappings apply only when all the conditions are satisfied. An empty mapping
+// means the character is removed.
+type SpecialCasingEntry struct {
+	CP         rune     `json:"cp"`
+	Lower      []rune   `json:"lower"`
+	Title      []rune   `json:"title"`
+	Upper      []rune   `json:"upper"`
+	Conditions []string `json:"conditions"`
+}
+
+// SpecialCasing represents the full case mappings SpecialCasing.txt defines. The entries are in the order of the file.
+type SpecialCasing struct {
+	Entries []*SpecialCasingEntry `json:"entries"`
+}
+
+type WordBreakProperty struct {
+	Entries map[PropertyValueSymbol][]*CodePointRange `json:"entries"`
+}
+
+type SentenceBreakProperty struct {
+	Entries map[PropertyValueSymbol][]*CodePointRange `json:"entries"`
+}
+
+// EmojiData represents the binary properties emoji-data.txt defines.
+type EmojiData struct {
+	Entries map[PropertyName][]*CodePointRange `json:"entries"`
+}
+
+// PropList represents the binary properties PropList.txt defines.
 type PropList struct {
-	WhiteSpace []*CodePointRange `json:"White_Space"`
+	Entries map[PropertyName][]*CodePointRange `json:"entries"`
 }
 
 type Unification struct {
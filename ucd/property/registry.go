@@ -0,0 +1,233 @@
+package property
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PropertyType is a type of a property. See section 5.2 Property Types in [UAX44].
+type PropertyType string
+
+const (
+	PropertyTypeCatalog       PropertyType = "Catalog"
+	PropertyTypeEnumerated    PropertyType = "Enumerated"
+	PropertyTypeBinary        PropertyType = "Binary"
+	PropertyTypeString        PropertyType = "String"
+	PropertyTypeNumeric       PropertyType = "Numeric"
+	PropertyTypeMiscellaneous PropertyType = "Miscellaneous"
+)
+
+var propertyTypes = []PropertyType{
+	PropertyTypeCatalog,
+	PropertyTypeEnumerated,
+	PropertyTypeBinary,
+	PropertyTypeString,
+	PropertyTypeNumeric,
+	PropertyTypeMiscellaneous,
+}
+
+// ParsePropertyType returns a property type corresponding to a name such as `Binary`. The name is case-insensitive.
+func ParsePropertyType(name string) (PropertyType, error) {
+	for _, t := range propertyTypes {
+		if strings.EqualFold(name, string(t)) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown property type: %v", name)
+}
+
+// uax44Property is an entry of the property table in section 5.3 Property Definitions in [UAX44]. `abb` is needed
+// only for properties PropertyAliases.txt of the supported version doesn't define. `def` is a default value of a
+// property whose default value PropertyValueAliases.txt doesn't specify.
+type uax44Property struct {
+	abb   PropertyName
+	typ   PropertyType
+	files []string
+	def   string
+}
+
+// uax44Properties holds the types and the source files of the properties. The Unihan properties, whose names start
+// with `k`, are omitted; their source is always the Unihan database.
+var uax44Properties = map[PropertyName]*uax44Property{
+	// Catalog properties
+	"Age":    {typ: PropertyTypeCatalog, files: []string{"DerivedAge.txt"}},
+	"Block":  {typ: PropertyTypeCatalog, files: []string{"Blocks.txt"}},
+	"Script": {typ: PropertyTypeCatalog, files: []string{"Scripts.txt"}},
+
+	// Enumerated properties
+	"Bidi_Class":                {typ: PropertyTypeEnumerated, files: []string{"UnicodeData.txt"}},
+	"Bidi_Paired_Bracket_Type":  {typ: PropertyTypeEnumerated, files: []string{"BidiBrackets.txt"}},
+	"Canonical_Combining_Class": {typ: PropertyTypeEnumerated, files: []string{"UnicodeData.txt"}},
+	"Decomposition_Type":        {typ: PropertyTypeEnumerated, files: []string{"UnicodeData.txt"}},
+	"East_Asian_Width":          {typ: PropertyTypeEnumerated, files: []string{"EastAsianWidth.txt"}},
+	"General_Category":          {typ: PropertyTypeEnumerated, files: []string{"UnicodeData.txt"}},
+	"Grapheme_Cluster_Break":    {typ: PropertyTypeEnumerated, files: []string{"GraphemeBreakProperty.txt"}, def: "other"},
+	"Hangul_Syllable_Type":      {typ: PropertyTypeEnumerated, files: []string{"HangulSyllableType.txt"}},
+	"Indic_Conjunct_Break":      {abb: "InCB", typ: PropertyTypeEnumerated, files: []string{"DerivedCoreProperties.txt"}, def: "none"},
+	"Indic_Positional_Category": {typ: PropertyTypeEnumerated, files: []string{"IndicPositionalCategory.txt"}},
+	"Indic_Syllabic_Category":   {typ: PropertyTypeEnumerated, files: []string{"IndicSyllabicCategory.txt"}},
+	"Joining_Group":             {typ: PropertyTypeEnumerated, files: []string{"ArabicShaping.txt"}},
+	"Joining_Type":              {typ: PropertyTypeEnumerated, files: []string{"ArabicShaping.txt"}},
+	"Line_Break":                {typ: PropertyTypeEnumerated, files: []string{"LineBreak.txt"}},
+	"NFC_Quick_Check":           {typ: PropertyTypeEnumerated, files: []string{"DerivedNormalizationProps.txt"}},
+	"NFD_Quick_Check":           {typ: PropertyTypeEnumerated, files: []string{"DerivedNormalizationProps.txt"}},
+	"NFKC_Quick_Check":          {typ: PropertyTypeEnumerated, files: []string{"DerivedNormalizationProps.txt"}},
+	"NFKD_Quick_Check":          {typ: PropertyTypeEnumerated, files: []string{"DerivedNormalizationProps.txt"}},
+	"Numeric_Type":              {typ: PropertyTypeEnumerated, files: []string{"UnicodeData.txt"}},
+	"Sentence_Break":            {typ: PropertyTypeEnumerated, files: []string{"SentenceBreakProperty.txt"}, def: "other"},
+	"Vertical_Orientation":      {typ: PropertyTypeEnumerated, files: []string{"VerticalOrientation.txt"}},
+	"Word_Break":                {typ: PropertyTypeEnumerated, files: []string{"WordBreakProperty.txt"}, def: "other"},
+
+	// String properties
+	"Bidi_Mirroring_Glyph":         {typ: PropertyTypeString, files: []string{"BidiMirroring.txt"}},
+	"Bidi_Paired_Bracket":          {typ: PropertyTypeString, files: []string{"BidiBrackets.txt"}},
+	"Case_Folding":                 {typ: PropertyTypeString, files: []string{"CaseFolding.txt"}},
+	"Decomposition_Mapping":        {typ: PropertyTypeString, files: []string{"UnicodeData.txt"}},
+	"Equivalent_Unified_Ideograph": {typ: PropertyTypeString, files: []string{"EquivalentUnifiedIdeograph.txt"}},
+	"FC_NFKC_Closure":              {typ: PropertyTypeString, files: []string{"DerivedNormalizationProps.txt"}},
+	"Lowercase_Mapping":            {typ: PropertyTypeString, files: []string{"UnicodeData.txt", "SpecialCasing.txt"}},
+	"NFKC_Casefold":                {typ: PropertyTypeString, files: []string{"DerivedNormalizationProps.txt"}},
+	"Simple_Case_Folding":          {typ: PropertyTypeString, files: []string{"CaseFolding.txt"}},
+	"Simple_Lowercase_Mapping":     {typ: PropertyTypeString, files: []string{"UnicodeData.txt"}},
+	"Simple_Titlecase_Mapping":     {typ: PropertyTypeString, files: []string{"UnicodeData.txt"}},
+	"Simple_Uppercase_Mapping":     {typ: PropertyTypeString, files: []string{"UnicodeData.txt"}},
+	"Titlecase_Mapping":            {typ: PropertyTypeString, files: []string{"UnicodeData.txt", "SpecialCasing.txt"}},
+	"Uppercase_Mapping":            {typ: PropertyTypeString, files: []string{"UnicodeData.txt", "SpecialCasing.txt"}},
+
+	// Numeric properties
+	"Numeric_Value": {typ: PropertyTypeNumeric, files: []string{"UnicodeData.txt"}},
+
+	// Miscellaneous properties
+	"ISO_Comment":       {typ: PropertyTypeMiscellaneous, files: []string{"UnicodeData.txt"}},
+	"Jamo_Short_Name":   {typ: PropertyTypeMiscellaneous, files: []string{"Jamo.txt"}},
+	"Name":              {typ: PropertyTypeMiscellaneous, files: []string{"UnicodeData.txt"}},
+	"Name_Alias":        {typ: PropertyTypeMiscellaneous, files: []string{"NameAliases.txt"}},
+	"Script_Extensions": {typ: PropertyTypeMiscellaneous, files: []string{"ScriptExtensions.txt"}},
+	"Unicode_1_Name":    {typ: PropertyTypeMiscellaneous, files: []string{"UnicodeData.txt"}},
+
+	// Binary properties
+	"Bidi_Mirrored":                {typ: PropertyTypeBinary, files: []string{"UnicodeData.txt"}},
+	"Composition_Exclusion":        {typ: PropertyTypeBinary, files: []string{"CompositionExclusions.txt"}},
+	"Full_Composition_Exclusion":   {typ: PropertyTypeBinary, files: []string{"DerivedNormalizationProps.txt"}},
+	"Changes_When_NFKC_Casefolded": {typ: PropertyTypeBinary, files: []string{"DerivedNormalizationProps.txt"}},
+	"Expands_On_NFC":               {typ: PropertyTypeBinary, files: []string{"DerivedNormalizationProps.txt"}},
+	"Expands_On_NFD":               {typ: PropertyTypeBinary, files: []string{"DerivedNormalizationProps.txt"}},
+	"Expands_On_NFKC":              {typ: PropertyTypeBinary, files: []string{"DerivedNormalizationProps.txt"}},
+	"Expands_On_NFKD":              {typ: PropertyTypeBinary, files: []string{"DerivedNormalizationProps.txt"}},
+	"Emoji":                        {typ: PropertyTypeBinary, files: []string{"emoji-data.txt"}},
+	"Emoji_Presentation":           {typ: PropertyTypeBinary, files: []string{"emoji-data.txt"}},
+	"Emoji_Modifier":               {typ: PropertyTypeBinary, files: []string{"emoji-data.txt"}},
+	"Emoji_Modifier_Base":          {typ: PropertyTypeBinary, files: []string{"emoji-data.txt"}},
+	"Emoji_Component":              {typ: PropertyTypeBinary, files: []string{"emoji-data.txt"}},
+	"Extended_Pictographic":        {typ: PropertyTypeBinary, files: []string{"emoji-data.txt"}},
+}
+
+func init() {
+	for _, name := range DerivedCorePropertyNames {
+		uax44Properties[name] = &uax44Property{typ: PropertyTypeBinary, files: []string{"DerivedCoreProperties.txt"}}
+	}
+	for _, name := range PropListPropertyNames {
+		uax44Properties[name] = &uax44Property{typ: PropertyTypeBinary, files: []string{"PropList.txt"}}
+	}
+}
+
+// PropertyMetadata describes a property. `Default` is the default value of code points not listed in the source
+// files; it is empty when the property has no default value ucdx knows.
+type PropertyMetadata struct {
+	Abb     PropertyName   `json:"abb"`
+	Long    PropertyName   `json:"long"`
+	Others  []PropertyName `json:"others"`
+	Type    PropertyType   `json:"type"`
+	Files   []string       `json:"files"`
+	Default string         `json:"default"`
+}
+
+// PropertyRegistry holds the metadata of properties.
+type PropertyRegistry struct {
+	Properties []*PropertyMetadata
+}
+
+// NewPropertyRegistry makes a registry of the properties PropertyAliases.txt defines and the ones newer than the
+// file. The types come from the headings of PropertyAliases.txt, and the property table of [UAX44] complements them
+// with the source files. The default values of the enumerated and the catalog properties come from the `@missing`
+// lines of PropertyValueAliases.txt.
+func NewPropertyRegistry(propAliases *PropertyAliases, propValAliases *PropertyValueAliases) *PropertyRegistry {
+	var props []*PropertyMetadata
+	defined := map[PropertyName]bool{}
+	for _, a := range propAliases.Aliases {
+		defined[a.Long] = true
+		props = append(props, newPropertyMetadata(a.Abb, a.Long, a.Others, a.Type, propValAliases))
+	}
+	var extras []*PropertyMetadata
+	for long, p := range uax44Properties {
+		if defined[long] || p.abb == "" {
+			continue
+		}
+		extras = append(extras, newPropertyMetadata(p.abb, long, nil, p.typ, propValAliases))
+	}
+	sort.Slice(extras, func(i, j int) bool {
+		return extras[i].Long < extras[j].Long
+	})
+	props = append(props, extras...)
+	return &PropertyRegistry{
+		Properties: props,
+	}
+}
+
+func newPropertyMetadata(abb, long PropertyName, others []PropertyName, typ PropertyType, propValAliases *PropertyValueAliases) *PropertyMetadata {
+	files := []string{"Unihan"}
+	var def string
+	if p, ok := uax44Properties[long]; ok {
+		files = p.files
+		def = p.def
+		if typ == "" {
+			typ = p.typ
+		}
+	}
+	if typ == "" {
+		typ = PropertyTypeMiscellaneous
+	}
+
+	// Section 5.7.1 Default Values in [UAX44] defines the default values of the properties other than the enumerated
+	// and the catalog ones.
+	switch typ {
+	case PropertyTypeBinary:
+		def = "No"
+	case PropertyTypeNumeric:
+		def = "NaN"
+	case PropertyTypeString:
+		def = "<code point>"
+	case PropertyTypeCatalog, PropertyTypeEnumerated:
+		if v, ok := propValAliases.DefaultValues[long]; ok {
+			def = v.Value.String()
+		}
+	}
+	if long == PropNameScriptExtensions {
+		def = "<script>"
+	}
+
+	return &PropertyMetadata{
+		Abb:     abb,
+		Long:    long,
+		Others:  others,
+		Type:    typ,
+		Files:   files,
+		Default: def,
+	}
+}
+
+// Lookup returns the metadata of a property. The name may be any alias of the property.
+func (r *PropertyRegistry) Lookup(name PropertyName) *PropertyMetadata {
+	for _, p := range r.Properties {
+		if p.Abb == name || p.Long == name {
+			return p
+		}
+		for _, o := range p.Others {
+			if o == name {
+				return p
+			}
+		}
+	}
+	return nil
+}
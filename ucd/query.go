@@ -0,0 +1,320 @@
+package ucd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Query returns the sorted and merged code point ranges an expression selects. The expression consists of terms
+// like `sc=Greek` combined with the following operators in descending order of precedence:
+//
+//	!a     complement
+//	a & b  intersection
+//	a - b  difference
+//	a | b  union
+//
+// Operators of the same precedence associate to the left, and parentheses group subexpressions. A term without a
+// value such as `White_Space` selects the code points having the binary property. Both the property and the value
+// of a term may be any of their aliases, matched loosely following UAX44-LM3; so a term may contain spaces and
+// hyphens like `gc=Decimal-Number`. A hyphen is a difference operator only when it follows a space or `)`.
+func (u *UCD) Query(expr string) ([]*property.CodePointRange, error) {
+	q, err := parseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.eval(u)
+}
+
+type queryOp int
+
+const (
+	queryOpTerm queryOp = iota
+	queryOpNot
+	queryOpAnd
+	queryOpDiff
+	queryOpOr
+)
+
+// queryNode is a node of the syntax tree of a query. A term node has `name` and `value`, and the other nodes have
+// operands.
+type queryNode struct {
+	op    queryOp
+	name  string
+	value string
+	left  *queryNode
+	right *queryNode
+}
+
+func (n *queryNode) eval(u *UCD) ([]*property.CodePointRange, error) {
+	if n.op == queryOpTerm {
+		return u.CodePointRanges(n.name, n.value)
+	}
+	if n.op == queryOpNot {
+		cps, err := n.left.eval(u)
+		if err != nil {
+			return nil, err
+		}
+		return complementRanges(cps), nil
+	}
+
+	left, err := n.left.eval(u)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(u)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case queryOpAnd:
+		return intersectRanges(left, right), nil
+	case queryOpDiff:
+		return intersectRanges(left, complementRanges(right)), nil
+	}
+	return mergeCodePointRanges(append(append([]*property.CodePointRange{}, left...), right...)), nil
+}
+
+// String returns the expression in a canonical form with full parentheses.
+func (n *queryNode) String() string {
+	switch n.op {
+	case queryOpTerm:
+		return fmt.Sprintf("%v=%v", n.name, n.value)
+	case queryOpNot:
+		return fmt.Sprintf("!%v", n.left)
+	case queryOpAnd:
+		return fmt.Sprintf("(%v & %v)", n.left, n.right)
+	case queryOpDiff:
+		return fmt.Sprintf("(%v - %v)", n.left, n.right)
+	}
+	return fmt.Sprintf("(%v | %v)", n.left, n.right)
+}
+
+type queryTokenKind int
+
+const (
+	queryTokenEOF queryTokenKind = iota
+	queryTokenTerm
+	queryTokenNot
+	queryTokenAnd
+	queryTokenDiff
+	queryTokenOr
+	queryTokenLParen
+	queryTokenRParen
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+var queryOperators = map[rune]queryTokenKind{
+	'!': queryTokenNot,
+	'&': queryTokenAnd,
+	'|': queryTokenOr,
+	'(': queryTokenLParen,
+	')': queryTokenRParen,
+}
+
+func lexQuery(expr string) []*queryToken {
+	var tokens []*queryToken
+	src := []rune(expr)
+	// A hyphen at the start of a token is a difference operator.
+	atBoundary := true
+	for i := 0; i < len(src); {
+		c := src[i]
+		if unicode.IsSpace(c) {
+			atBoundary = true
+			i++
+			continue
+		}
+		if kind, ok := queryOperators[c]; ok {
+			tokens = append(tokens, &queryToken{kind: kind, text: string(c), pos: i})
+			atBoundary = true
+			i++
+			continue
+		}
+		if c == '-' && atBoundary {
+			tokens = append(tokens, &queryToken{kind: queryTokenDiff, text: "-", pos: i})
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(src) {
+			if _, ok := queryOperators[src[i]]; ok {
+				break
+			}
+			if src[i] == '-' && unicode.IsSpace(src[i-1]) {
+				break
+			}
+			i++
+		}
+		tokens = append(tokens, &queryToken{
+			kind: queryTokenTerm,
+			text: strings.TrimSpace(string(src[start:i])),
+			pos:  start,
+		})
+		atBoundary = i > 0 && unicode.IsSpace(src[i-1])
+	}
+	return append(tokens, &queryToken{kind: queryTokenEOF, pos: len(src)})
+}
+
+// queryParser is a recursive descent parser of queries.
+type queryParser struct {
+	tokens []*queryToken
+	pos    int
+}
+
+func parseQuery(expr string) (*queryNode, error) {
+	p := &queryParser{
+		tokens: lexQuery(expr),
+	}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != queryTokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %v", t.text, t.pos)
+	}
+	return n, nil
+}
+
+func (p *queryParser) peek() *queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() *queryToken {
+	t := p.tokens[p.pos]
+	if t.kind != queryTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	left, err := p.parseDiff()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == queryTokenOr {
+		p.next()
+		right, err := p.parseDiff()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: queryOpOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseDiff() (*queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == queryTokenDiff {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: queryOpDiff, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == queryTokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: queryOpAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	t := p.next()
+	switch t.kind {
+	case queryTokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{op: queryOpNot, left: operand}, nil
+	case queryTokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.kind != queryTokenRParen {
+			return nil, fmt.Errorf("missing ) at %v", r.pos)
+		}
+		return n, nil
+	case queryTokenTerm:
+		name, value := t.text, "Yes"
+		if i := strings.Index(t.text, "="); i >= 0 {
+			name, value = strings.TrimSpace(t.text[:i]), strings.TrimSpace(t.text[i+1:])
+		}
+		if name == "" || value == "" {
+			return nil, fmt.Errorf("invalid term %q at %v", t.text, t.pos)
+		}
+		return &queryNode{op: queryOpTerm, name: name, value: value}, nil
+	case queryTokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at %v", t.text, t.pos)
+}
+
+// complementRanges returns the code points not in sorted and merged ranges.
+func complementRanges(cps []*property.CodePointRange) []*property.CodePointRange {
+	var comp []*property.CodePointRange
+	next := rune(0)
+	for _, cp := range cps {
+		from, to := cp.Range()
+		if from > next {
+			comp = append(comp, property.NewCodePointRange(next, from-1))
+		}
+		next = to + 1
+	}
+	if next <= unicode.MaxRune {
+		comp = append(comp, property.NewCodePointRange(next, unicode.MaxRune))
+	}
+	return comp
+}
+
+// intersectRanges returns the code points in both of sorted and merged ranges.
+func intersectRanges(a, b []*property.CodePointRange) []*property.CodePointRange {
+	var inter []*property.CodePointRange
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		aFrom, aTo := a[i].Range()
+		bFrom, bTo := b[j].Range()
+		from, to := aFrom, aTo
+		if bFrom > from {
+			from = bFrom
+		}
+		if bTo < to {
+			to = bTo
+		}
+		if from <= to {
+			inter = append(inter, property.NewCodePointRange(from, to))
+		}
+		if aTo < bTo {
+			i++
+		} else {
+			j++
+		}
+	}
+	return inter
+}
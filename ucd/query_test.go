@@ -0,0 +1,103 @@
+package ucd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{
+			expr:     "sc=Greek & gc=Lu & !Deprecated",
+			expected: "((sc=Greek & gc=Lu) & !Deprecated=Yes)",
+		},
+		{
+			expr:     "a | b - c & d",
+			expected: "(a=Yes | (b=Yes - (c=Yes & d=Yes)))",
+		},
+		{
+			expr:     "(a | b) - c",
+			expected: "((a=Yes | b=Yes) - c=Yes)",
+		},
+		{
+			expr:     "gc = Decimal-Number - General Category=Nd",
+			expected: "(gc=Decimal-Number - General Category=Nd)",
+		},
+		{
+			expr:     "!!a",
+			expected: "!!a=Yes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			q, err := parseQuery(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.String() != tt.expected {
+				t.Fatalf("unexpected syntax tree: want: %v, got: %v", tt.expected, q)
+			}
+		})
+	}
+
+	for _, expr := range []string{"", "a &", "(a | b", "a b)", "a & & b", "=Lu", "- a"} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := parseQuery(expr); err == nil {
+				t.Fatalf("an error must occur")
+			}
+		})
+	}
+}
+
+func TestRangeAlgebra(t *testing.T) {
+	a := []*property.CodePointRange{
+		property.NewCodePointRange(0x10, 0x1F),
+		property.NewCodePointRange(0x30, 0x3F),
+	}
+	b := []*property.CodePointRange{
+		property.NewCodePointRange(0x18, 0x37),
+	}
+
+	tests := []struct {
+		caption  string
+		actual   []*property.CodePointRange
+		expected string
+	}{
+		{
+			caption:  "intersection",
+			actual:   intersectRanges(a, b),
+			expected: "[[24 31] [48 55]]",
+		},
+		{
+			caption:  "union",
+			actual:   mergeCodePointRanges(append(append([]*property.CodePointRange{}, a...), b...)),
+			expected: "[[16 63]]",
+		},
+		{
+			caption:  "difference",
+			actual:   intersectRanges(a, complementRanges(b)),
+			expected: "[[16 23] [56 63]]",
+		},
+		{
+			caption:  "complement",
+			actual:   complementRanges(b),
+			expected: "[[0 23] [56 1114111]]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caption, func(t *testing.T) {
+			var actual []property.CodePointRange
+			for _, cp := range tt.actual {
+				actual = append(actual, *cp)
+			}
+			if s := fmt.Sprint(actual); s != tt.expected {
+				t.Fatalf("unexpected ranges: want: %v, got: %v", tt.expected, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package segment
+
+import (
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Values of the Grapheme_Cluster_Break property. See section 3.1 Default Grapheme Cluster Boundary Specification
+// in [UAX29].
+const (
+	gcbOther = iota
+	gcbCR
+	gcbLF
+	gcbControl
+	gcbExtend
+	gcbZWJ
+	gcbRegionalIndicator
+	gcbPrepend
+	gcbSpacingMark
+	gcbL
+	gcbV
+	gcbT
+	gcbLV
+	gcbLVT
+)
+
+var gcbClasses = map[property.PropertyValueSymbol]int{
+	"cr":                gcbCR,
+	"lf":                gcbLF,
+	"control":           gcbControl,
+	"extend":            gcbExtend,
+	"zwj":               gcbZWJ,
+	"regionalindicator": gcbRegionalIndicator,
+	"prepend":           gcbPrepend,
+	"spacingmark":       gcbSpacingMark,
+	"l":                 gcbL,
+	"v":                 gcbV,
+	"t":                 gcbT,
+	"lv":                gcbLV,
+	"lvt":               gcbLVT,
+}
+
+// GraphemeBreaker determines extended grapheme cluster boundaries.
+type GraphemeBreaker struct {
+	gcb     classTable
+	extPict classTable
+}
+
+func NewGraphemeBreaker(gbp *property.GraphemeBreakProperty, emoji *property.EmojiData) *GraphemeBreaker {
+	return &GraphemeBreaker{
+		gcb:     newClassTable(gbp.Entries, gcbClasses),
+		extPict: newBinaryTable(emoji.Entries[property.PropNameExtendedPictographic]),
+	}
+}
+
+// Boundaries determines boundaries following the rules in section 3.1.1 Grapheme Cluster Boundary Rules in [UAX29].
+func (b *GraphemeBreaker) Boundaries(rs []rune) []*Boundary {
+	bounds := make([]*Boundary, len(rs)+1)
+	bounds[0] = &Boundary{Break: true, Rule: "GB1"}
+	if len(rs) == 0 {
+		return bounds
+	}
+
+	classes := make([]int, len(rs))
+	extPict := make([]bool, len(rs))
+	// pict[i] is true when rs[i] ends a sequence matching `\p{Extended_Pictographic} Extend*`.
+	pict := make([]bool, len(rs))
+	for i, c := range rs {
+		classes[i] = b.gcb.lookup(c)
+		extPict[i] = b.extPict.lookup(c) == 1
+		if extPict[i] {
+			pict[i] = true
+		} else if i > 0 && classes[i] == gcbExtend {
+			pict[i] = pict[i-1]
+		}
+	}
+
+	// ris is the number of consecutive Regional_Indicator characters before the current position.
+	ris := 0
+	for i := 1; i < len(rs); i++ {
+		prev := classes[i-1]
+		if prev == gcbRegionalIndicator {
+			ris++
+		} else {
+			ris = 0
+		}
+		bounds[i] = decideGraphemeBoundary(classes, extPict, pict, ris, i)
+	}
+	bounds[len(rs)] = &Boundary{Break: true, Rule: "GB2"}
+
+	return bounds
+}
+
+func decideGraphemeBoundary(classes []int, extPict []bool, pict []bool, ris int, i int) *Boundary {
+	prev := classes[i-1]
+	cur := classes[i]
+
+	switch {
+	case prev == gcbCR && cur == gcbLF:
+		return &Boundary{Break: false, Rule: "GB3"}
+	case prev == gcbControl || prev == gcbCR || prev == gcbLF:
+		return &Boundary{Break: true, Rule: "GB4"}
+	case cur == gcbControl || cur == gcbCR || cur == gcbLF:
+		return &Boundary{Break: true, Rule: "GB5"}
+	case prev == gcbL && (cur == gcbL || cur == gcbV || cur == gcbLV || cur == gcbLVT):
+		return &Boundary{Break: false, Rule: "GB6"}
+	case (prev == gcbLV || prev == gcbV) && (cur == gcbV || cur == gcbT):
+		return &Boundary{Break: false, Rule: "GB7"}
+	case (prev == gcbLVT || prev == gcbT) && cur == gcbT:
+		return &Boundary{Break: false, Rule: "GB8"}
+	case cur == gcbExtend || cur == gcbZWJ:
+		return &Boundary{Break: false, Rule: "GB9"}
+	case cur == gcbSpacingMark:
+		return &Boundary{Break: false, Rule: "GB9a"}
+	case prev == gcbPrepend:
+		return &Boundary{Break: false, Rule: "GB9b"}
+	case prev == gcbZWJ && extPict[i] && i >= 2 && pict[i-2]:
+		return &Boundary{Break: false, Rule: "GB11"}
+	case prev == gcbRegionalIndicator && cur == gcbRegionalIndicator && ris%2 == 1:
+		// GB12 and GB13 are the same rule except for the start of text.
+		if ris == i {
+			return &Boundary{Break: false, Rule: "GB12"}
+		}
+		return &Boundary{Break: false, Rule: "GB13"}
+	}
+	return &Boundary{Break: true, Rule: "GB999"}
+}
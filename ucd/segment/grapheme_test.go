@@ -0,0 +1,122 @@
+package segment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+const testGraphemeBreakProperty = `
+000D          ; CR # Cc       <control-000D>
+000A          ; LF # Cc       <control-000A>
+0000..0009    ; Control # Cc  [10] <control-0000>..<control-0009>
+0300..036F    ; Extend # Mn [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+1F3FB..1F3FF  ; Extend # Sk   [5] EMOJI MODIFIER FITZPATRICK TYPE-1-2..EMOJI MODIFIER FITZPATRICK TYPE-6
+200D          ; ZWJ # Cf       ZERO WIDTH JOINER
+1F1E6..1F1FF  ; Regional_Indicator # So  [26] REGIONAL INDICATOR SYMBOL LETTER A..REGIONAL INDICATOR SYMBOL LETTER Z
+0600..0605    ; Prepend # Cf   [6] ARABIC NUMBER SIGN..ARABIC NUMBER MARK ABOVE
+0903          ; SpacingMark # Mc       DEVANAGARI SIGN VISARGA
+1100..115F    ; L # Lo  [96] HANGUL CHOSEONG KIYEOK..HANGUL CHOSEONG FILLER
+1160..11A7    ; V # Lo  [72] HANGUL JUNGSEONG FILLER..HANGUL JUNGSEONG O-YAE
+11A8..11FF    ; T # Lo  [88] HANGUL JONGSEONG KIYEOK..HANGUL JONGSEONG SSANGNIEUN
+AC00          ; LV # Lo       HANGUL SYLLABLE GA
+AC01..AC1B    ; LVT # Lo  [27] HANGUL SYLLABLE GAG..HANGUL SYLLABLE GAH
+`
+
+const testEmojiData = `
+1F468..1F469  ; Extended_Pictographic# E0.6   [2] (👨..👩)    man..woman
+1F466         ; Extended_Pictographic# E0.6   [1] (👦)       boy
+`
+
+func TestGraphemeBreaker(t *testing.T) {
+	gbp, err := parser.ParseGraphemeBreakProperty(strings.NewReader(testGraphemeBreakProperty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emoji, err := parser.ParseEmojiData(strings.NewReader(testEmojiData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewGraphemeBreaker(gbp, emoji)
+
+	tests := []struct {
+		src      []rune
+		clusters [][]rune
+		rules    []Rule
+	}{
+		{
+			src:      []rune{'a', 0x000D, 0x000A, 'b'},
+			clusters: [][]rune{{'a'}, {0x000D, 0x000A}, {'b'}},
+			rules:    []Rule{"GB1", "GB5", "GB3", "GB4", "GB2"},
+		},
+		{
+			src:      []rune{'e', 0x0301, 0x0903, 'f'},
+			clusters: [][]rune{{'e', 0x0301, 0x0903}, {'f'}},
+			rules:    []Rule{"GB1", "GB9", "GB9a", "GB999", "GB2"},
+		},
+		{
+			src:      []rune{0x0600, 'a'},
+			clusters: [][]rune{{0x0600, 'a'}},
+			rules:    []Rule{"GB1", "GB9b", "GB2"},
+		},
+		{
+			src:      []rune{0x1100, 0x1161, 0x11A8, 0xAC00, 0x11A8, 0xAC01, 0x11A8, 0x1100},
+			clusters: [][]rune{{0x1100, 0x1161, 0x11A8}, {0xAC00, 0x11A8}, {0xAC01, 0x11A8}, {0x1100}},
+			rules:    []Rule{"GB1", "GB6", "GB7", "GB999", "GB7", "GB999", "GB8", "GB999", "GB2"},
+		},
+		{
+			src:      []rune{0x1F468, 0x1F3FB, 0x200D, 0x1F469, 0x200D, 0x1F466, 'a', 0x200D, 0x1F466},
+			clusters: [][]rune{{0x1F468, 0x1F3FB, 0x200D, 0x1F469, 0x200D, 0x1F466}, {'a', 0x200D}, {0x1F466}},
+			rules:    []Rule{"GB1", "GB9", "GB9", "GB11", "GB9", "GB11", "GB999", "GB9", "GB999", "GB2"},
+		},
+		{
+			src:      []rune{0x1F1EF, 0x1F1F5, 0x1F1EF, 'a', 0x1F1EF, 0x1F1F5, 0x1F1EF, 0x1F1F5, 0x1F1EF},
+			clusters: [][]rune{{0x1F1EF, 0x1F1F5}, {0x1F1EF}, {'a'}, {0x1F1EF, 0x1F1F5}, {0x1F1EF, 0x1F1F5}, {0x1F1EF}},
+			rules:    []Rule{"GB1", "GB12", "GB999", "GB999", "GB999", "GB13", "GB999", "GB13", "GB999", "GB2"},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			clusters := Split(b, tt.src)
+			if fmt.Sprintf("%X", clusters) != fmt.Sprintf("%X", tt.clusters) {
+				t.Fatalf("unexpected clusters: want: %X, got: %X", tt.clusters, clusters)
+			}
+			bounds := b.Boundaries(tt.src)
+			if len(bounds) != len(tt.rules) {
+				t.Fatalf("unexpected boundaries: want: %v, got: %v", len(tt.rules), len(bounds))
+			}
+			for i, bound := range bounds {
+				if bound.Rule != tt.rules[i] {
+					t.Fatalf("unexpected rule at %v: want: %v, got: %v", i, tt.rules[i], bound.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestRule_Number(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		num  string
+	}{
+		{rule: "GB1", num: "0.2"},
+		{rule: "GB2", num: "0.3"},
+		{rule: "GB9", num: "9.0"},
+		{rule: "GB9a", num: "9.1"},
+		{rule: "GB9b", num: "9.2"},
+		{rule: "GB999", num: "999.0"},
+		{rule: "LB2", num: "0.2"},
+		{rule: "LB3", num: "0.3"},
+		{rule: "LB8a", num: "8.1"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			num := tt.rule.Number()
+			if num != tt.num {
+				t.Fatalf("unexpected number: want: %v, got: %v", tt.num, num)
+			}
+		})
+	}
+}
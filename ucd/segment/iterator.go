@@ -0,0 +1,137 @@
+package segment
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// Segment is a segment of UTF-8 encoded text.
+type Segment struct {
+	// Bytes is the text of the segment.
+	Bytes []byte
+
+	// Start and End are byte offsets of the segment in the whole text. The segment occupies [Start, End).
+	Start int
+	End   int
+
+	// Boundary is the decision at the end of the segment. When an iterator reads text from an io.Reader, the decision
+	// at the end of each line is the one at the end of text.
+	Boundary *Boundary
+}
+
+// Iterator iterates over segments of UTF-8 encoded text. Invalid bytes are treated as U+FFFD, and each of them
+// occupies one byte of the text.
+//
+// When an iterator reads text from an io.Reader, it processes the text line by line. This is safe because the
+// grapheme cluster, word, sentence, and line boundary rules always break after LF, and no rule looks past LF.
+type Iterator struct {
+	breaker Breaker
+	r       *bufio.Reader
+	err     error
+
+	// chunk is the text being processed, and base is the byte offset of the chunk in the whole text.
+	chunk []byte
+	base  int
+
+	// offsets are byte offsets of boundaries in the chunk, and bounds are the decisions at them. pos is an index of
+	// the next segment's start.
+	offsets []int
+	bounds  []*Boundary
+	pos     int
+
+	seg *Segment
+}
+
+// NewIterator returns an iterator over segments of a byte slice.
+func NewIterator(b Breaker, src []byte) *Iterator {
+	it := &Iterator{
+		breaker: b,
+	}
+	it.setChunk(src)
+	return it
+}
+
+// NewReaderIterator returns an iterator over segments of text an io.Reader provides.
+func NewReaderIterator(b Breaker, r io.Reader) *Iterator {
+	return &Iterator{
+		breaker: b,
+		r:       bufio.NewReader(r),
+	}
+}
+
+// Next advances the iterator to the next segment. It returns false when there are no more segments or an error
+// occurs. Call Err to distinguish the two cases.
+func (it *Iterator) Next() bool {
+	for it.pos+1 >= len(it.offsets) {
+		if it.r == nil || it.err != nil {
+			it.seg = nil
+			return false
+		}
+		line, err := it.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			it.err = err
+			it.seg = nil
+			return false
+		}
+		if len(line) == 0 && err == io.EOF {
+			it.r = nil
+			continue
+		}
+		it.base += len(it.chunk)
+		it.setChunk(line)
+	}
+
+	from := it.offsets[it.pos]
+	to := it.offsets[it.pos+1]
+	it.pos++
+	it.seg = &Segment{
+		Bytes:    it.chunk[from:to],
+		Start:    it.base + from,
+		End:      it.base + to,
+		Boundary: it.bounds[it.pos],
+	}
+	return true
+}
+
+// Segment returns the current segment.
+func (it *Iterator) Segment() *Segment {
+	return it.seg
+}
+
+// Err returns the first error that occurred while reading text.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) setChunk(chunk []byte) {
+	var rs []rune
+	var runeOffsets []int
+	for i := 0; i < len(chunk); {
+		c, size := utf8.DecodeRune(chunk[i:])
+		rs = append(rs, c)
+		runeOffsets = append(runeOffsets, i)
+		i += size
+	}
+	runeOffsets = append(runeOffsets, len(chunk))
+
+	allBounds := it.breaker.Boundaries(rs)
+	offsets := []int{0}
+	bounds := []*Boundary{allBounds[0]}
+	for i, bound := range allBounds {
+		if i == 0 || i == len(rs) || !bound.Break {
+			continue
+		}
+		offsets = append(offsets, runeOffsets[i])
+		bounds = append(bounds, bound)
+	}
+	if len(chunk) > 0 {
+		offsets = append(offsets, len(chunk))
+		bounds = append(bounds, allBounds[len(rs)])
+	}
+
+	it.chunk = chunk
+	it.offsets = offsets
+	it.bounds = bounds
+	it.pos = 0
+}
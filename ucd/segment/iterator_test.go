@@ -0,0 +1,56 @@
+package segment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+func TestIterator(t *testing.T) {
+	wbp, err := parser.ParseWordBreakProperty(strings.NewReader(testWordBreakProperty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emoji, err := parser.ParseEmojiData(strings.NewReader(testEmojiData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewWordBreaker(wbp, emoji)
+
+	src := "he\u0301 it's\r\nok\xFF\n"
+	want := []*Segment{
+		{Bytes: []byte("he\u0301"), Start: 0, End: 4},
+		{Bytes: []byte(" "), Start: 4, End: 5},
+		{Bytes: []byte("it's"), Start: 5, End: 9},
+		{Bytes: []byte("\r\n"), Start: 9, End: 11},
+		{Bytes: []byte("ok"), Start: 11, End: 13},
+		{Bytes: []byte("\xFF"), Start: 13, End: 14},
+		{Bytes: []byte("\n"), Start: 14, End: 15},
+	}
+
+	iters := map[string]*Iterator{
+		"bytes":  NewIterator(b, []byte(src)),
+		"reader": NewReaderIterator(b, strings.NewReader(src)),
+	}
+	for name, it := range iters {
+		t.Run(name, func(t *testing.T) {
+			var segs []*Segment
+			for it.Next() {
+				segs = append(segs, it.Segment())
+			}
+			if it.Err() != nil {
+				t.Fatal(it.Err())
+			}
+			if len(segs) != len(want) {
+				t.Fatalf("unexpected segments: want: %v, got: %v", len(want), len(segs))
+			}
+			for i, seg := range segs {
+				if fmt.Sprintf("%q %v %v", seg.Bytes, seg.Start, seg.End) != fmt.Sprintf("%q %v %v", want[i].Bytes, want[i].Start, want[i].End) {
+					t.Fatalf("unexpected segment at %v: want: %q [%v, %v), got: %q [%v, %v)", i, want[i].Bytes, want[i].Start, want[i].End, seg.Bytes, seg.Start, seg.End)
+				}
+			}
+		})
+	}
+}
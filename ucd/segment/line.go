@@ -0,0 +1,495 @@
+package segment
+
+import (
+	"strconv"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Values of the Line_Break property. See section 5.1 Description of Line Breaking Properties in [UAX14].
+//
+// lbXX must be zero because the classTable returns zero for code points LineBreak.txt doesn't list.
+const (
+	lbXX = iota
+	lbBK
+	lbCR
+	lbLF
+	lbCM
+	lbNL
+	lbSG
+	lbWJ
+	lbZW
+	lbGL
+	lbSP
+	lbZWJ
+	lbB2
+	lbBA
+	lbBB
+	lbHY
+	lbCB
+	lbCL
+	lbCP
+	lbEX
+	lbIN
+	lbNS
+	lbOP
+	lbQU
+	lbIS
+	lbNU
+	lbPO
+	lbPR
+	lbSY
+	lbAI
+	lbAL
+	lbCJ
+	lbEB
+	lbEM
+	lbH2
+	lbH3
+	lbHL
+	lbID
+	lbJL
+	lbJV
+	lbJT
+	lbRI
+	lbSA
+
+	lbClassCount
+)
+
+var lbClasses = map[property.PropertyValueSymbol]int{
+	"bk":  lbBK,
+	"cr":  lbCR,
+	"lf":  lbLF,
+	"cm":  lbCM,
+	"nl":  lbNL,
+	"sg":  lbSG,
+	"wj":  lbWJ,
+	"zw":  lbZW,
+	"gl":  lbGL,
+	"sp":  lbSP,
+	"zwj": lbZWJ,
+	"b2":  lbB2,
+	"ba":  lbBA,
+	"bb":  lbBB,
+	"hy":  lbHY,
+	"cb":  lbCB,
+	"cl":  lbCL,
+	"cp":  lbCP,
+	"ex":  lbEX,
+	"in":  lbIN,
+	"ns":  lbNS,
+	"op":  lbOP,
+	"qu":  lbQU,
+	"is":  lbIS,
+	"nu":  lbNU,
+	"po":  lbPO,
+	"pr":  lbPR,
+	"sy":  lbSY,
+	"ai":  lbAI,
+	"al":  lbAL,
+	"cj":  lbCJ,
+	"eb":  lbEB,
+	"em":  lbEM,
+	"h2":  lbH2,
+	"h3":  lbH3,
+	"hl":  lbHL,
+	"id":  lbID,
+	"jl":  lbJL,
+	"jv":  lbJV,
+	"jt":  lbJT,
+	"ri":  lbRI,
+	"sa":  lbSA,
+	"xx":  lbXX,
+}
+
+// lbPairRule is a rule deciding a boundary between a pair of classes. A nil class set matches any class. When
+// `spaces` is true, the rule also applies when spaces intervene between the pair, such as `OP SP* ×`.
+type lbPairRule struct {
+	rule   Rule
+	brk    bool
+	spaces bool
+	before []int
+	after  []int
+}
+
+// lbPairRules are the rules from LB11 to LB30b that can be decided only by a pair of classes. The other rules need
+// more context, and decideLineBoundary handles them.
+var lbPairRules = []*lbPairRule{
+	{rule: "LB11", after: []int{lbWJ}},
+	{rule: "LB11", before: []int{lbWJ}},
+	{rule: "LB12", before: []int{lbGL}},
+	{rule: "LB12a", before: lbClassesExcept(lbSP, lbBA, lbHY), after: []int{lbGL}},
+	{rule: "LB13", after: []int{lbCL, lbCP, lbEX, lbIS, lbSY}},
+	{rule: "LB14", spaces: true, before: []int{lbOP}},
+	{rule: "LB15", spaces: true, before: []int{lbQU}, after: []int{lbOP}},
+	{rule: "LB16", spaces: true, before: []int{lbCL, lbCP}, after: []int{lbNS}},
+	{rule: "LB17", spaces: true, before: []int{lbB2}, after: []int{lbB2}},
+	{rule: "LB18", brk: true, before: []int{lbSP}},
+	{rule: "LB19", after: []int{lbQU}},
+	{rule: "LB19", before: []int{lbQU}},
+	{rule: "LB20", brk: true, after: []int{lbCB}},
+	{rule: "LB20", brk: true, before: []int{lbCB}},
+	{rule: "LB21", after: []int{lbBA, lbHY, lbNS}},
+	{rule: "LB21", before: []int{lbBB}},
+	{rule: "LB21b", before: []int{lbSY}, after: []int{lbHL}},
+	{rule: "LB22", after: []int{lbIN}},
+	{rule: "LB23", before: []int{lbAL, lbHL}, after: []int{lbNU}},
+	{rule: "LB23", before: []int{lbNU}, after: []int{lbAL, lbHL}},
+	{rule: "LB23a", before: []int{lbPR}, after: []int{lbID, lbEB, lbEM}},
+	{rule: "LB23a", before: []int{lbID, lbEB, lbEM}, after: []int{lbPO}},
+	{rule: "LB24", before: []int{lbPR, lbPO}, after: []int{lbAL, lbHL}},
+	{rule: "LB24", before: []int{lbAL, lbHL}, after: []int{lbPR, lbPO}},
+	{rule: "LB26", before: []int{lbJL}, after: []int{lbJL, lbJV, lbH2, lbH3}},
+	{rule: "LB26", before: []int{lbJV, lbH2}, after: []int{lbJV, lbJT}},
+	{rule: "LB26", before: []int{lbJT, lbH3}, after: []int{lbJT}},
+	{rule: "LB27", before: []int{lbJL, lbJV, lbJT, lbH2, lbH3}, after: []int{lbPO}},
+	{rule: "LB27", before: []int{lbPR}, after: []int{lbJL, lbJV, lbJT, lbH2, lbH3}},
+	{rule: "LB28", before: []int{lbAL, lbHL}, after: []int{lbAL, lbHL}},
+	{rule: "LB29", before: []int{lbIS}, after: []int{lbAL, lbHL}},
+	{rule: "LB30", before: []int{lbAL, lbHL, lbNU}, after: []int{lbOP}},
+	{rule: "LB30", before: []int{lbCP}, after: []int{lbAL, lbHL, lbNU}},
+	{rule: "LB30b", before: []int{lbEB}, after: []int{lbEM}},
+}
+
+func lbClassesExcept(classes ...int) []int {
+	var cs []int
+	for c := 0; c < lbClassCount; c++ {
+		excluded := false
+		for _, e := range classes {
+			if c == e {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// lbPairTable is the pair table described in section 7 Pair Table-Based Implementation in [UAX14]. An entry is nil
+// when no pair rule applies to the pair.
+var lbPairTable = newLBPairTable(lbPairRules)
+
+func newLBPairTable(rules []*lbPairRule) [lbClassCount][lbClassCount]*lbPairRule {
+	var t [lbClassCount][lbClassCount]*lbPairRule
+	all := lbClassesExcept()
+	// The rules are applied in reverse order so that an earlier rule takes precedence.
+	for i := len(rules) - 1; i >= 0; i-- {
+		r := rules[i]
+		before := r.before
+		if before == nil {
+			before = all
+		}
+		after := r.after
+		if after == nil {
+			after = all
+		}
+		for _, b := range before {
+			for _, a := range after {
+				t[b][a] = r
+			}
+		}
+	}
+	return t
+}
+
+// lbEastAsianClasses are the East_Asian_Width values that LB30 excludes.
+var lbEastAsianClasses = map[property.PropertyValueSymbol]int{
+	"f": 1,
+	"w": 1,
+	"h": 1,
+}
+
+const (
+	lbGCUnassigned = iota
+	lbGCAssigned
+	lbGCMark
+)
+
+// LineBreaker determines line break opportunities.
+type LineBreaker struct {
+	lb          classTable
+	defaults    []*property.DefaultValue
+	eastAsian   classTable
+	eawDefaults []*property.DefaultValue
+	gc          classTable
+	extPict     classTable
+}
+
+func NewLineBreaker(lb *property.LineBreak, eaw *property.EastAsianWidth, emoji *property.EmojiData, ud *property.UnicodeData) *LineBreaker {
+	gcClasses := map[property.PropertyValueSymbol]int{}
+	for gc := range ud.GeneralCategory {
+		gcClasses[gc] = lbGCAssigned
+	}
+	gcClasses["mn"] = lbGCMark
+	gcClasses["mc"] = lbGCMark
+
+	return &LineBreaker{
+		lb:          newClassTable(lb.Entries, lbClasses),
+		defaults:    lb.Defaults,
+		eastAsian:   newClassTable(eaw.Entries, lbEastAsianClasses),
+		eawDefaults: eaw.Defaults,
+		gc:          newClassTable(ud.GeneralCategory, gcClasses),
+		extPict:     newBinaryTable(emoji.Entries[property.PropNameExtendedPictographic]),
+	}
+}
+
+// isEastAsian returns true when the East_Asian_Width of a code point is Fullwidth (F), Wide (W), or Halfwidth (H).
+func (b *LineBreaker) isEastAsian(c rune) bool {
+	if b.eastAsian.lookup(c) == 1 {
+		return true
+	}
+	if v, ok := property.LookupDefaultValue(b.eawDefaults, c); ok {
+		return lbEastAsianClasses[v] == 1
+	}
+	return false
+}
+
+// resolveClass returns a class of a code point resolved by LB1.
+func (b *LineBreaker) resolveClass(c rune) int {
+	class := b.lb.lookup(c)
+	if class == lbXX {
+		if v, ok := property.LookupDefaultValue(b.defaults, c); ok {
+			class = lbClasses[v]
+		}
+	}
+
+	switch class {
+	case lbAI, lbSG, lbXX:
+		return lbAL
+	case lbSA:
+		if b.gc.lookup(c) == lbGCMark {
+			return lbCM
+		}
+		return lbAL
+	case lbCJ:
+		return lbNS
+	}
+	return class
+}
+
+// lineContext holds the classes of text. `classes` holds the classes LB1 resolves, and `effective` holds the classes
+// after LB9 and LB10 are applied. `absorbed[i]` is true when LB9 makes the i-th character a part of the preceding
+// character.
+type lineContext struct {
+	rs        []rune
+	classes   []int
+	effective []int
+	absorbed  []bool
+	eastAsian []bool
+	extPictCn []bool
+}
+
+// Boundaries determines boundaries following the rules in section 6 Line Breaking Algorithm in [UAX14]. The numbers
+// are handled using the regular expression in section 8.2 Examples of Customization, Example 7, as LineBreakTest.txt
+// does.
+func (b *LineBreaker) Boundaries(rs []rune) []*Boundary {
+	bounds := make([]*Boundary, len(rs)+1)
+	bounds[0] = &Boundary{Break: false, Rule: "LB2"}
+	if len(rs) == 0 {
+		return bounds
+	}
+
+	ctx := &lineContext{
+		rs:        rs,
+		classes:   make([]int, len(rs)),
+		effective: make([]int, len(rs)),
+		absorbed:  make([]bool, len(rs)),
+		eastAsian: make([]bool, len(rs)),
+		extPictCn: make([]bool, len(rs)),
+	}
+	for i, c := range rs {
+		class := b.resolveClass(c)
+		ctx.classes[i] = class
+		ctx.effective[i] = class
+		if class == lbCM || class == lbZWJ {
+			if i > 0 && !isLBNotAbsorbing(ctx.effective[i-1]) {
+				ctx.effective[i] = ctx.effective[i-1]
+				ctx.absorbed[i] = true
+			} else {
+				ctx.effective[i] = lbAL
+			}
+		}
+		ctx.eastAsian[i] = b.isEastAsian(c)
+		ctx.extPictCn[i] = b.extPict.lookup(c) == 1 && b.gc.lookup(c) == lbGCUnassigned
+	}
+
+	for i := 1; i < len(rs); i++ {
+		bounds[i] = decideLineBoundary(ctx, i)
+	}
+	bounds[len(rs)] = &Boundary{Break: true, Rule: "LB3", Mandatory: true}
+
+	return bounds
+}
+
+// isLBNotAbsorbing returns true when a class can't be `X` of LB9.
+func isLBNotAbsorbing(c int) bool {
+	return c == lbBK || c == lbCR || c == lbLF || c == lbNL || c == lbSP || c == lbZW
+}
+
+func decideLineBoundary(ctx *lineContext, i int) *Boundary {
+	prev := ctx.classes[i-1]
+	cur := ctx.classes[i]
+
+	switch {
+	case prev == lbBK:
+		return &Boundary{Break: true, Rule: "LB4", Mandatory: true}
+	case prev == lbCR && cur == lbLF:
+		return &Boundary{Break: false, Rule: "LB5"}
+	case prev == lbCR || prev == lbLF || prev == lbNL:
+		return &Boundary{Break: true, Rule: "LB5", Mandatory: true}
+	case cur == lbBK || cur == lbCR || cur == lbLF || cur == lbNL:
+		return &Boundary{Break: false, Rule: "LB6"}
+	case cur == lbSP || cur == lbZW:
+		return &Boundary{Break: false, Rule: "LB7"}
+	}
+
+	// sp is an index of a character preceding the spaces before the current position.
+	sp := i - 1
+	for sp >= 0 && ctx.classes[sp] == lbSP {
+		sp--
+	}
+	switch {
+	case sp >= 0 && ctx.classes[sp] == lbZW:
+		return &Boundary{Break: true, Rule: "LB8"}
+	case prev == lbZWJ:
+		return &Boundary{Break: false, Rule: "LB8a"}
+	case ctx.absorbed[i]:
+		return &Boundary{Break: false, Rule: "LB9"}
+	}
+
+	before := ctx.effective[i-1]
+	after := ctx.effective[i]
+
+	var r *lbPairRule
+	if before == lbSP {
+		r = lbPairTable[lbSP][after]
+		if sp >= 0 {
+			if s := lbPairTable[ctx.effective[sp]][after]; s != nil && s.spaces && s.rule.precedes(r.rule) {
+				r = s
+			}
+		}
+	} else {
+		r = lbPairTable[before][after]
+		// LB30 applies only to OP and CP whose East_Asian_Width is not F, W, or H.
+		if r != nil && r.rule == "LB30" && (after == lbOP && ctx.eastAsian[i] || before == lbCP && ctx.eastAsian[ctx.head(i-1)]) {
+			r = nil
+		}
+	}
+
+	// The rules that need more context than a pair of classes never break, so they matter only when they precede
+	// the rule from the pair table.
+	if ctxRule, ok := decideLineBoundaryByContext(ctx, i); ok && (r == nil || ctxRule.precedes(r.rule)) {
+		return &Boundary{Break: false, Rule: ctxRule}
+	}
+	if r != nil {
+		return &Boundary{Break: r.brk, Rule: r.rule}
+	}
+	return &Boundary{Break: true, Rule: "LB31"}
+}
+
+// decideLineBoundaryByContext applies LB21a, LB25, LB30a, and the part of LB30b that the pair table can't express.
+// It returns a rule that prohibits a break at the position i.
+func decideLineBoundaryByContext(ctx *lineContext, i int) (Rule, bool) {
+	before := ctx.effective[i-1]
+	after := ctx.effective[i]
+	// x is an index of the character the character before the current position belongs to by LB9.
+	x := ctx.head(i - 1)
+
+	switch {
+	case (before == lbHY || before == lbBA) && x > 0 && ctx.effective[x-1] == lbHL:
+		return "LB21a", true
+	case ctx.matchNumber(i):
+		return "LB25", true
+	case before == lbRI && after == lbRI:
+		// ris is the number of consecutive Regional_Indicator characters before the current position.
+		ris := 0
+		for j := x; j >= 0 && ctx.effective[j] == lbRI; j = ctx.head(j - 1) {
+			ris++
+		}
+		if ris%2 == 1 {
+			return "LB30a", true
+		}
+	case after == lbEM && ctx.extPictCn[x]:
+		return "LB30b", true
+	}
+	return "", false
+}
+
+// matchNumber applies the following rules that tailor LB25:
+//
+//	(PR | PO) × ( OP | HY )? NU
+//	( OP | HY ) × NU
+//	NU × (NU | SY | IS)
+//	NU (NU | SY | IS)* × (NU | SY | IS | CL | CP )
+//	NU (NU | SY | IS)* (CL | CP)? × (PO | PR)
+func (ctx *lineContext) matchNumber(i int) bool {
+	before := ctx.effective[i-1]
+	after := ctx.effective[i]
+
+	switch {
+	case before == lbPR || before == lbPO:
+		if after == lbNU {
+			return true
+		}
+		if after == lbOP || after == lbHY {
+			n := ctx.next(i)
+			return n < len(ctx.rs) && ctx.effective[n] == lbNU
+		}
+	case before == lbOP || before == lbHY:
+		return after == lbNU
+	}
+
+	switch after {
+	case lbNU, lbSY, lbIS, lbCL, lbCP:
+		return ctx.followNumber(i - 1)
+	case lbPO, lbPR:
+		j := i - 1
+		if before == lbCL || before == lbCP {
+			j = ctx.head(j) - 1
+		}
+		return ctx.followNumber(j)
+	}
+	return false
+}
+
+// followNumber returns true when the characters up to the position i match `NU (NU | SY | IS)*`.
+func (ctx *lineContext) followNumber(i int) bool {
+	for j := i; j >= 0; j-- {
+		switch ctx.effective[j] {
+		case lbNU:
+			return true
+		case lbSY, lbIS:
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// head returns an index of the character the i-th character belongs to by LB9.
+func (ctx *lineContext) head(i int) int {
+	for i > 0 && ctx.absorbed[i] {
+		i--
+	}
+	return i
+}
+
+// next returns an index of the character following the i-th character, skipping characters LB9 absorbs.
+func (ctx *lineContext) next(i int) int {
+	j := i + 1
+	for j < len(ctx.rs) && ctx.absorbed[j] {
+		j++
+	}
+	return j
+}
+
+// precedes returns true when a rule precedes another rule in the order of the rules.
+func (r Rule) precedes(s Rule) bool {
+	n1, _ := strconv.ParseFloat(r.Number(), 64)
+	n2, _ := strconv.ParseFloat(s.Number(), 64)
+	return n1 < n2
+}
@@ -0,0 +1,143 @@
+package segment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+const testLineBreak = `
+# @missing: 0000..10FFFF; XX
+# @missing: 3400..4DBF; ID
+000A;LF           # Cc       <control-000A>
+000D;CR           # Cc       <control-000D>
+0020;SP           # Zs       SPACE
+0024;PR           # Sc       DOLLAR SIGN
+0025;PO           # Po       PERCENT SIGN
+0028;OP           # Ps       LEFT PARENTHESIS
+FF08;OP           # Ps       FULLWIDTH LEFT PARENTHESIS
+0029;CP           # Pe       RIGHT PARENTHESIS
+002D;HY           # Pd       HYPHEN-MINUS
+002E;IS           # Po       FULL STOP
+0030..0039;NU     # Nd    [10] DIGIT ZERO..DIGIT NINE
+0041..005A;AL     # Lu    [26] LATIN CAPITAL LETTER A..LATIN CAPITAL LETTER Z
+0061..007A;AL     # Ll    [26] LATIN SMALL LETTER A..LATIN SMALL LETTER Z
+0300..036F;CM     # Mn   [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+05D0..05EA;HL     # Lo    [27] HEBREW LETTER ALEF..HEBREW LETTER TAV
+200B;ZW           # Cf       ZERO WIDTH SPACE
+200D;ZWJ          # Cf       ZERO WIDTH JOINER
+2060;WJ           # Cf       WORD JOINER
+261D;EB           # So       WHITE UP POINTING INDEX
+3042;ID           # Lo       HIRAGANA LETTER A
+1F1E6..1F1FF;RI   # So    [26] REGIONAL INDICATOR SYMBOL LETTER A..REGIONAL INDICATOR SYMBOL LETTER Z
+1F3FB..1F3FF;EM   # Sk     [5] EMOJI MODIFIER FITZPATRICK TYPE-1-2..EMOJI MODIFIER FITZPATRICK TYPE-6
+`
+
+const testLineBreakEastAsianWidth = `
+FF08;F           # Ps         FULLWIDTH LEFT PARENTHESIS
+`
+
+const testLineBreakUnicodeData = `
+0041;LATIN CAPITAL LETTER A;Lu;0;L;;;;;N;;;;0061;
+0300;COMBINING GRAVE ACCENT;Mn;230;NSM;;;;;N;NON-SPACING GRAVE;;;;
+`
+
+func TestLineBreaker(t *testing.T) {
+	lb, err := parser.ParseLineBreak(strings.NewReader(testLineBreak))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eaw, err := parser.ParseEastAsianWidth(strings.NewReader(testLineBreakEastAsianWidth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emoji, err := parser.ParseEmojiData(strings.NewReader(testEmojiData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ud, err := parser.ParseUnicodeData(strings.NewReader(testLineBreakUnicodeData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewLineBreaker(lb, eaw, emoji, ud)
+
+	tests := []struct {
+		src       string
+		segments  []string
+		rules     []Rule
+		mandatory []int
+	}{
+		{
+			src:       "ab cd\r\nef",
+			segments:  []string{"ab ", "cd\r\n", "ef"},
+			rules:     []Rule{"LB2", "LB28", "LB7", "LB18", "LB28", "LB6", "LB5", "LB5", "LB28", "LB3"},
+			mandatory: []int{7, 9},
+		},
+		{
+			src:       "$(12.5)% x",
+			segments:  []string{"$(12.5)% ", "x"},
+			rules:     []Rule{"LB2", "LB25", "LB14", "LB25", "LB13", "LB25", "LB13", "LB25", "LB7", "LB18", "LB3"},
+			mandatory: []int{10},
+		},
+		{
+			src:       "א-ב a\u0301\u200Dあ",
+			segments:  []string{"א-ב ", "a\u0301\u200Dあ"},
+			rules:     []Rule{"LB2", "LB21", "LB21a", "LB7", "LB18", "LB9", "LB9", "LB8a", "LB3"},
+			mandatory: []int{8},
+		},
+		{
+			src:       "\U0001F1E6\U0001F1E7\U0001F1E8☝\U0001F3FB㐁",
+			segments:  []string{"\U0001F1E6\U0001F1E7", "\U0001F1E8", "☝\U0001F3FB", "㐁"},
+			rules:     []Rule{"LB2", "LB30a", "LB31", "LB31", "LB30b", "LB31", "LB3"},
+			mandatory: []int{6},
+		},
+		{
+			src:       "a\u200B b\u2060c",
+			segments:  []string{"a\u200B ", "b\u2060c"},
+			rules:     []Rule{"LB2", "LB7", "LB7", "LB8", "LB11", "LB11", "LB3"},
+			mandatory: []int{6},
+		},
+		{
+			src:       "a(b\uFF08c",
+			segments:  []string{"a(b", "\uFF08c"},
+			rules:     []Rule{"LB2", "LB30", "LB14", "LB31", "LB14", "LB3"},
+			mandatory: []int{5},
+		},
+		{
+			src:       "( a",
+			segments:  []string{"( a"},
+			rules:     []Rule{"LB2", "LB7", "LB14", "LB3"},
+			mandatory: []int{3},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			src := []rune(tt.src)
+			var segs []string
+			for _, s := range Split(b, src) {
+				segs = append(segs, string(s))
+			}
+			if fmt.Sprintf("%q", segs) != fmt.Sprintf("%q", tt.segments) {
+				t.Fatalf("unexpected segments: want: %q, got: %q", tt.segments, segs)
+			}
+			bounds := b.Boundaries(src)
+			if len(bounds) != len(tt.rules) {
+				t.Fatalf("unexpected boundaries: want: %v, got: %v", len(tt.rules), len(bounds))
+			}
+			var mandatory []int
+			for i, bound := range bounds {
+				if bound.Rule != tt.rules[i] {
+					t.Fatalf("unexpected rule at %v: want: %v, got: %v", i, tt.rules[i], bound.Rule)
+				}
+				if bound.Mandatory {
+					mandatory = append(mandatory, i)
+				}
+			}
+			if fmt.Sprint(mandatory) != fmt.Sprint(tt.mandatory) {
+				t.Fatalf("unexpected mandatory breaks: want: %v, got: %v", tt.mandatory, mandatory)
+			}
+		})
+	}
+}
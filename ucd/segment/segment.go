@@ -0,0 +1,124 @@
+// Package segment implements the text segmentation algorithms using the data the UCD provides.
+//
+// See [UAX29] for more details on the grapheme cluster, word, and sentence boundaries, and [UAX14] for the line
+// breaking.
+package segment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Rule is a name of a rule that determines a boundary, such as `GB9a`.
+type Rule string
+
+// Number returns a rule number in the notation of the test data files such as GraphemeBreakTest.txt. For instance,
+// the number of `GB9a` is `9.1`, and the numbers of the rules for the start and the end of text are `0.2` and `0.3`.
+// The line breaking rules for the start and the end of text are `LB2` and `LB3` instead of `LB1` and `LB2`.
+func (r Rule) Number() string {
+	s := strings.TrimLeft(string(r), "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	sot, eot := "1", "2"
+	if strings.HasPrefix(string(r), "LB") {
+		sot, eot = "2", "3"
+	}
+	switch s {
+	case sot:
+		return "0.2"
+	case eot:
+		return "0.3"
+	}
+	i := strings.IndexFunc(s, func(c rune) bool {
+		return c < '0' || c > '9'
+	})
+	if i < 0 {
+		return s + ".0"
+	}
+	return fmt.Sprintf("%v.%v", s[:i], s[i]-'a'+1)
+}
+
+// Boundary represents a decision on whether there is a boundary at a position of text.
+type Boundary struct {
+	Break bool `json:"break"`
+	Rule  Rule `json:"rule"`
+
+	// Mandatory is true when a line must break at the position. Only the line breaking sets this field.
+	Mandatory bool `json:"mandatory"`
+}
+
+// Breaker determines boundaries of text.
+type Breaker interface {
+	// Boundaries returns decisions at all positions of a sequence of code points. The length of the result is
+	// len(rs)+1, and the i-th element is a decision on the position just before rs[i].
+	Boundaries(rs []rune) []*Boundary
+}
+
+// Split splits a sequence of code points into segments at boundaries a breaker determines.
+func Split(b Breaker, rs []rune) [][]rune {
+	var segs [][]rune
+	start := 0
+	for i, bound := range b.Boundaries(rs) {
+		if i == 0 || !bound.Break {
+			continue
+		}
+		segs = append(segs, rs[start:i])
+		start = i
+	}
+	return segs
+}
+
+type classRange struct {
+	from  rune
+	to    rune
+	class int
+}
+
+// classTable maps code points to classes such as values of Grapheme_Cluster_Break. The table is sorted by code
+// points so that it can be searched in logarithmic time.
+type classTable []*classRange
+
+// newClassTable makes a table from the data of an enumerated property. Values not included in `classes` are
+// ignored, and code points having such values belong to class 0.
+func newClassTable(entries map[property.PropertyValueSymbol][]*property.CodePointRange, classes map[property.PropertyValueSymbol]int) classTable {
+	var t classTable
+	for v, cps := range entries {
+		class, ok := classes[v]
+		if !ok {
+			continue
+		}
+		for _, cp := range cps {
+			from, to := cp.Range()
+			t = append(t, &classRange{
+				from:  from,
+				to:    to,
+				class: class,
+			})
+		}
+	}
+	sort.Slice(t, func(i, j int) bool {
+		return t[i].from < t[j].from
+	})
+	return t
+}
+
+// newBinaryTable makes a table from the data of a binary property. Code points having the property belong to
+// class 1, and the others belong to class 0.
+func newBinaryTable(cps []*property.CodePointRange) classTable {
+	return newClassTable(map[property.PropertyValueSymbol][]*property.CodePointRange{
+		"y": cps,
+	}, map[property.PropertyValueSymbol]int{
+		"y": 1,
+	})
+}
+
+func (t classTable) lookup(c rune) int {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].to >= c
+	})
+	if i < len(t) && t[i].from <= c {
+		return t[i].class
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+package segment
+
+import (
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Values of the Sentence_Break property. See section 5.1 Default Sentence Boundary Specification in [UAX29].
+const (
+	sbOther = iota
+	sbCR
+	sbLF
+	sbExtend
+	sbSep
+	sbFormat
+	sbSp
+	sbLower
+	sbUpper
+	sbOLetter
+	sbNumeric
+	sbATerm
+	sbSContinue
+	sbSTerm
+	sbClose
+)
+
+var sbClasses = map[property.PropertyValueSymbol]int{
+	"cr":        sbCR,
+	"lf":        sbLF,
+	"extend":    sbExtend,
+	"sep":       sbSep,
+	"format":    sbFormat,
+	"sp":        sbSp,
+	"lower":     sbLower,
+	"upper":     sbUpper,
+	"oletter":   sbOLetter,
+	"numeric":   sbNumeric,
+	"aterm":     sbATerm,
+	"scontinue": sbSContinue,
+	"sterm":     sbSTerm,
+	"close":     sbClose,
+}
+
+// SentenceBreaker determines sentence boundaries.
+type SentenceBreaker struct {
+	sb classTable
+}
+
+func NewSentenceBreaker(sbp *property.SentenceBreakProperty) *SentenceBreaker {
+	return &SentenceBreaker{
+		sb: newClassTable(sbp.Entries, sbClasses),
+	}
+}
+
+// Boundaries determines boundaries following the rules in section 5.1.1 Sentence Boundary Rules in [UAX29].
+func (b *SentenceBreaker) Boundaries(rs []rune) []*Boundary {
+	bounds := make([]*Boundary, len(rs)+1)
+	bounds[0] = &Boundary{Break: true, Rule: "SB1"}
+	if len(rs) == 0 {
+		return bounds
+	}
+
+	classes := make([]int, len(rs))
+	for i, c := range rs {
+		classes[i] = b.sb.lookup(c)
+	}
+
+	for i := 1; i < len(rs); i++ {
+		bounds[i] = decideSentenceBoundary(classes, i)
+	}
+	bounds[len(rs)] = &Boundary{Break: true, Rule: "SB2"}
+
+	return bounds
+}
+
+func decideSentenceBoundary(classes []int, i int) *Boundary {
+	prev := classes[i-1]
+	cur := classes[i]
+
+	switch {
+	case prev == sbCR && cur == sbLF:
+		return &Boundary{Break: false, Rule: "SB3"}
+	case isParaSep(prev):
+		return &Boundary{Break: true, Rule: "SB4"}
+	case cur == sbExtend || cur == sbFormat:
+		return &Boundary{Break: false, Rule: "SB5"}
+	}
+
+	// The following rules see through characters SB5 makes ignored.
+	p := prevSentenceClassIndex(classes, i)
+	prev = classes[p]
+	prev2 := -1
+	if p > 0 {
+		prev2 = classes[prevSentenceClassIndex(classes, p)]
+	}
+	// term is the class of a character matching `SATerm Close* Sp*` immediately before the current position, and
+	// closeOnly is true when the sequence contains no Sp.
+	term, closeOnly := matchSATermCloseSp(classes, i)
+
+	switch {
+	case prev == sbATerm && cur == sbNumeric:
+		return &Boundary{Break: false, Rule: "SB6"}
+	case (prev2 == sbUpper || prev2 == sbLower) && prev == sbATerm && cur == sbUpper:
+		return &Boundary{Break: false, Rule: "SB7"}
+	case term == sbATerm && followedByLower(classes, i):
+		return &Boundary{Break: false, Rule: "SB8"}
+	case term >= 0 && (cur == sbSContinue || isSATerm(cur)):
+		return &Boundary{Break: false, Rule: "SB8a"}
+	case term >= 0 && closeOnly && (cur == sbClose || cur == sbSp || isParaSep(cur)):
+		return &Boundary{Break: false, Rule: "SB9"}
+	case term >= 0 && (cur == sbSp || isParaSep(cur)):
+		return &Boundary{Break: false, Rule: "SB10"}
+	case term >= 0:
+		return &Boundary{Break: true, Rule: "SB11"}
+	}
+	return &Boundary{Break: false, Rule: "SB998"}
+}
+
+// matchSATermCloseSp matches `SATerm Close* Sp*` backward from the position i. It returns the class of the SATerm
+// character, or -1 when the sequence doesn't match. The second result is true when the sequence contains no Sp.
+func matchSATermCloseSp(classes []int, i int) (int, bool) {
+	j := i
+	closeOnly := true
+	for j > 0 {
+		p := prevSentenceClassIndex(classes, j)
+		if classes[p] != sbSp {
+			break
+		}
+		closeOnly = false
+		j = p
+	}
+	for j > 0 {
+		p := prevSentenceClassIndex(classes, j)
+		if classes[p] != sbClose {
+			break
+		}
+		j = p
+	}
+	if j == 0 {
+		return -1, false
+	}
+	p := prevSentenceClassIndex(classes, j)
+	if !isSATerm(classes[p]) {
+		return -1, false
+	}
+	return classes[p], closeOnly
+}
+
+// followedByLower reports whether the characters from the position i match
+// `( ¬(OLetter | Upper | Lower | ParaSep | SATerm) )* Lower`.
+func followedByLower(classes []int, i int) bool {
+	for _, c := range classes[i:] {
+		switch {
+		case c == sbLower:
+			return true
+		case c == sbOLetter || c == sbUpper || isParaSep(c) || isSATerm(c):
+			return false
+		}
+	}
+	return false
+}
+
+// prevSentenceClassIndex returns an index of a character preceding the position i, skipping characters SB5 makes
+// ignored. A sequence of Extend and Format characters is ignored only when it follows a character other than
+// ParaSep.
+func prevSentenceClassIndex(classes []int, i int) int {
+	j := i - 1
+	for j > 0 && (classes[j] == sbExtend || classes[j] == sbFormat) && !isParaSep(classes[j-1]) {
+		j--
+	}
+	return j
+}
+
+func isParaSep(c int) bool {
+	return c == sbSep || c == sbCR || c == sbLF
+}
+
+func isSATerm(c int) bool {
+	return c == sbSTerm || c == sbATerm
+}
@@ -0,0 +1,84 @@
+package segment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+const testSentenceBreakProperty = `
+000D          ; CR # Cc       <control-000D>
+000A          ; LF # Cc       <control-000A>
+0300..036F    ; Extend # Mn [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+2029          ; Sep # Zp       PARAGRAPH SEPARATOR
+00AD          ; Format # Cf       SOFT HYPHEN
+0020          ; Sp # Zs       SPACE
+0061..007A    ; Lower # L&  [26] LATIN SMALL LETTER A..LATIN SMALL LETTER Z
+0041..005A    ; Upper # L&  [26] LATIN CAPITAL LETTER A..LATIN CAPITAL LETTER Z
+05D0..05EA    ; OLetter # Lo  [27] HEBREW LETTER ALEF..HEBREW LETTER TAV
+0030..0039    ; Numeric # Nd  [10] DIGIT ZERO..DIGIT NINE
+002E          ; ATerm # Po       FULL STOP
+002C          ; SContinue # Po       COMMA
+0021          ; STerm # Po       EXCLAMATION MARK
+003F          ; STerm # Po       QUESTION MARK
+0022          ; Close # Po       QUOTATION MARK
+0029          ; Close # Pe       RIGHT PARENTHESIS
+`
+
+func TestSentenceBreaker(t *testing.T) {
+	sbp, err := parser.ParseSentenceBreakProperty(strings.NewReader(testSentenceBreakProperty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewSentenceBreaker(sbp)
+
+	tests := []struct {
+		src       string
+		sentences []string
+		rules     []Rule
+	}{
+		{
+			src:       "Hi! Go.",
+			sentences: []string{"Hi! ", "Go."},
+			rules:     []Rule{"SB1", "SB998", "SB998", "SB9", "SB11", "SB998", "SB998", "SB2"},
+		},
+		{
+			src:       "U.S. a 1.5",
+			sentences: []string{"U.S. a 1.5"},
+			rules:     []Rule{"SB1", "SB998", "SB7", "SB998", "SB8", "SB8", "SB998", "SB998", "SB998", "SB6", "SB2"},
+		},
+		{
+			src:       "Ok.\") No\r\nYes",
+			sentences: []string{"Ok.\") ", "No\r\n", "Yes"},
+			rules:     []Rule{"SB1", "SB998", "SB998", "SB9", "SB9", "SB9", "SB11", "SB998", "SB998", "SB3", "SB4", "SB998", "SB998", "SB2"},
+		},
+		{
+			src:       "a.\u0301, b?! c",
+			sentences: []string{"a.\u0301, b?! ", "c"},
+			rules:     []Rule{"SB1", "SB998", "SB5", "SB8", "SB998", "SB998", "SB998", "SB8a", "SB9", "SB11", "SB2"},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			src := []rune(tt.src)
+			var sentences []string
+			for _, s := range Split(b, src) {
+				sentences = append(sentences, string(s))
+			}
+			if fmt.Sprintf("%q", sentences) != fmt.Sprintf("%q", tt.sentences) {
+				t.Fatalf("unexpected sentences: want: %q, got: %q", tt.sentences, sentences)
+			}
+			bounds := b.Boundaries(src)
+			if len(bounds) != len(tt.rules) {
+				t.Fatalf("unexpected boundaries: want: %v, got: %v", len(tt.rules), len(bounds))
+			}
+			for i, bound := range bounds {
+				if bound.Rule != tt.rules[i] {
+					t.Fatalf("unexpected rule at %v: want: %v, got: %v", i, tt.rules[i], bound.Rule)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package segment
+
+import (
+	"github.com/nihei9/ucdx/ucd/property"
+)
+
+// Values of the Word_Break property. See section 4.1 Default Word Boundary Specification in [UAX29].
+const (
+	wbOther = iota
+	wbCR
+	wbLF
+	wbNewline
+	wbExtend
+	wbZWJ
+	wbRegionalIndicator
+	wbFormat
+	wbKatakana
+	wbHebrewLetter
+	wbALetter
+	wbSingleQuote
+	wbDoubleQuote
+	wbMidNumLet
+	wbMidLetter
+	wbMidNum
+	wbNumeric
+	wbExtendNumLet
+	wbWSegSpace
+)
+
+var wbClasses = map[property.PropertyValueSymbol]int{
+	"cr":                wbCR,
+	"lf":                wbLF,
+	"newline":           wbNewline,
+	"extend":            wbExtend,
+	"zwj":               wbZWJ,
+	"regionalindicator": wbRegionalIndicator,
+	"format":            wbFormat,
+	"katakana":          wbKatakana,
+	"hebrewletter":      wbHebrewLetter,
+	"aletter":           wbALetter,
+	"singlequote":       wbSingleQuote,
+	"doublequote":       wbDoubleQuote,
+	"midnumlet":         wbMidNumLet,
+	"midletter":         wbMidLetter,
+	"midnum":            wbMidNum,
+	"numeric":           wbNumeric,
+	"extendnumlet":      wbExtendNumLet,
+	"wsegspace":         wbWSegSpace,
+}
+
+// WordBreaker determines word boundaries.
+type WordBreaker struct {
+	wb      classTable
+	extPict classTable
+}
+
+func NewWordBreaker(wbp *property.WordBreakProperty, emoji *property.EmojiData) *WordBreaker {
+	return &WordBreaker{
+		wb:      newClassTable(wbp.Entries, wbClasses),
+		extPict: newBinaryTable(emoji.Entries[property.PropNameExtendedPictographic]),
+	}
+}
+
+// Boundaries determines boundaries following the rules in section 4.1.1 Word Boundary Rules in [UAX29].
+func (b *WordBreaker) Boundaries(rs []rune) []*Boundary {
+	bounds := make([]*Boundary, len(rs)+1)
+	bounds[0] = &Boundary{Break: true, Rule: "WB1"}
+	if len(rs) == 0 {
+		return bounds
+	}
+
+	classes := make([]int, len(rs))
+	extPict := make([]bool, len(rs))
+	for i, c := range rs {
+		classes[i] = b.wb.lookup(c)
+		extPict[i] = b.extPict.lookup(c) == 1
+	}
+
+	for i := 1; i < len(rs); i++ {
+		bounds[i] = decideWordBoundary(classes, extPict, i)
+	}
+	bounds[len(rs)] = &Boundary{Break: true, Rule: "WB2"}
+
+	return bounds
+}
+
+func decideWordBoundary(classes []int, extPict []bool, i int) *Boundary {
+	prev := classes[i-1]
+	cur := classes[i]
+
+	switch {
+	case prev == wbCR && cur == wbLF:
+		return &Boundary{Break: false, Rule: "WB3"}
+	case isWBNewline(prev):
+		return &Boundary{Break: true, Rule: "WB3a"}
+	case isWBNewline(cur):
+		return &Boundary{Break: true, Rule: "WB3b"}
+	case prev == wbZWJ && extPict[i]:
+		return &Boundary{Break: false, Rule: "WB3c"}
+	case prev == wbWSegSpace && cur == wbWSegSpace:
+		return &Boundary{Break: false, Rule: "WB3d"}
+	case isWBIgnorable(cur):
+		return &Boundary{Break: false, Rule: "WB4"}
+	}
+
+	// The following rules see through characters WB4 makes ignored.
+	p := prevWordClassIndex(classes, i)
+	prev = classes[p]
+	prev2 := -1
+	if p > 0 {
+		prev2 = classes[prevWordClassIndex(classes, p)]
+	}
+	next := -1
+	if n := nextWordClassIndex(classes, i); n < len(classes) {
+		next = classes[n]
+	}
+
+	switch {
+	case isAHLetter(prev) && isAHLetter(cur):
+		return &Boundary{Break: false, Rule: "WB5"}
+	case isAHLetter(prev) && (cur == wbMidLetter || isMidNumLetQ(cur)) && isAHLetter(next):
+		return &Boundary{Break: false, Rule: "WB6"}
+	case isAHLetter(prev2) && (prev == wbMidLetter || isMidNumLetQ(prev)) && isAHLetter(cur):
+		return &Boundary{Break: false, Rule: "WB7"}
+	case prev == wbHebrewLetter && cur == wbSingleQuote:
+		return &Boundary{Break: false, Rule: "WB7a"}
+	case prev == wbHebrewLetter && cur == wbDoubleQuote && next == wbHebrewLetter:
+		return &Boundary{Break: false, Rule: "WB7b"}
+	case prev2 == wbHebrewLetter && prev == wbDoubleQuote && cur == wbHebrewLetter:
+		return &Boundary{Break: false, Rule: "WB7c"}
+	case prev == wbNumeric && cur == wbNumeric:
+		return &Boundary{Break: false, Rule: "WB8"}
+	case isAHLetter(prev) && cur == wbNumeric:
+		return &Boundary{Break: false, Rule: "WB9"}
+	case prev == wbNumeric && isAHLetter(cur):
+		return &Boundary{Break: false, Rule: "WB10"}
+	case prev2 == wbNumeric && (prev == wbMidNum || isMidNumLetQ(prev)) && cur == wbNumeric:
+		return &Boundary{Break: false, Rule: "WB11"}
+	case prev == wbNumeric && (cur == wbMidNum || isMidNumLetQ(cur)) && next == wbNumeric:
+		return &Boundary{Break: false, Rule: "WB12"}
+	case prev == wbKatakana && cur == wbKatakana:
+		return &Boundary{Break: false, Rule: "WB13"}
+	case (isAHLetter(prev) || prev == wbNumeric || prev == wbKatakana || prev == wbExtendNumLet) && cur == wbExtendNumLet:
+		return &Boundary{Break: false, Rule: "WB13a"}
+	case prev == wbExtendNumLet && (isAHLetter(cur) || cur == wbNumeric || cur == wbKatakana):
+		return &Boundary{Break: false, Rule: "WB13b"}
+	case prev == wbRegionalIndicator && cur == wbRegionalIndicator:
+		// ris is the number of consecutive Regional_Indicator characters before the current position.
+		ris := 0
+		start := p
+		for j := p; j >= 0 && classes[j] == wbRegionalIndicator; {
+			ris++
+			start = j
+			if j == 0 {
+				break
+			}
+			j = prevWordClassIndex(classes, j)
+		}
+		if ris%2 == 0 {
+			break
+		}
+		// WB15 and WB16 are the same rule except for the start of text.
+		if start == 0 {
+			return &Boundary{Break: false, Rule: "WB15"}
+		}
+		return &Boundary{Break: false, Rule: "WB16"}
+	}
+	return &Boundary{Break: true, Rule: "WB999"}
+}
+
+// prevWordClassIndex returns an index of a character preceding the position i, skipping characters WB4 makes
+// ignored. A sequence of Extend, Format, and ZWJ characters is ignored only when it follows a character other than
+// CR, LF, and Newline.
+func prevWordClassIndex(classes []int, i int) int {
+	j := i - 1
+	for j > 0 && isWBIgnorable(classes[j]) && !isWBNewline(classes[j-1]) {
+		j--
+	}
+	return j
+}
+
+// nextWordClassIndex returns an index of a character following the character at the position i, skipping
+// characters WB4 makes ignored.
+func nextWordClassIndex(classes []int, i int) int {
+	j := i + 1
+	for j < len(classes) && isWBIgnorable(classes[j]) {
+		j++
+	}
+	return j
+}
+
+func isWBNewline(c int) bool {
+	return c == wbNewline || c == wbCR || c == wbLF
+}
+
+func isWBIgnorable(c int) bool {
+	return c == wbExtend || c == wbFormat || c == wbZWJ
+}
+
+func isAHLetter(c int) bool {
+	return c == wbALetter || c == wbHebrewLetter
+}
+
+func isMidNumLetQ(c int) bool {
+	return c == wbMidNumLet || c == wbSingleQuote
+}
@@ -0,0 +1,101 @@
+package segment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+const testWordBreakProperty = `
+000D          ; CR # Cc       <control-000D>
+000A          ; LF # Cc       <control-000A>
+0085          ; Newline # Cc       <control-0085>
+0300..036F    ; Extend # Mn [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+200D          ; ZWJ # Cf       ZERO WIDTH JOINER
+00AD          ; Format # Cf       SOFT HYPHEN
+1F1E6..1F1FF  ; Regional_Indicator # So  [26] REGIONAL INDICATOR SYMBOL LETTER A..REGIONAL INDICATOR SYMBOL LETTER Z
+30A1..30FA    ; Katakana # Lo  [90] KATAKANA LETTER SMALL A..KATAKANA LETTER VO
+05D0..05EA    ; Hebrew_Letter # Lo  [27] HEBREW LETTER ALEF..HEBREW LETTER TAV
+0041..005A    ; ALetter # L&  [26] LATIN CAPITAL LETTER A..LATIN CAPITAL LETTER Z
+0061..007A    ; ALetter # L&  [26] LATIN SMALL LETTER A..LATIN SMALL LETTER Z
+0027          ; Single_Quote # Po       APOSTROPHE
+0022          ; Double_Quote # Po       QUOTATION MARK
+002E          ; MidNumLet # Po       FULL STOP
+003A          ; MidLetter # Po       COLON
+002C          ; MidNum # Po       COMMA
+0030..0039    ; Numeric # Nd  [10] DIGIT ZERO..DIGIT NINE
+005F          ; ExtendNumLet # Pc       LOW LINE
+0020          ; WSegSpace # Zs       SPACE
+`
+
+func TestWordBreaker(t *testing.T) {
+	wbp, err := parser.ParseWordBreakProperty(strings.NewReader(testWordBreakProperty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emoji, err := parser.ParseEmojiData(strings.NewReader(testEmojiData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewWordBreaker(wbp, emoji)
+
+	tests := []struct {
+		src   string
+		words []string
+		rules []Rule
+	}{
+		{
+			src:   "can't  stop",
+			words: []string{"can't", "  ", "stop"},
+			rules: []Rule{"WB1", "WB5", "WB5", "WB6", "WB7", "WB999", "WB3d", "WB999", "WB5", "WB5", "WB5", "WB2"},
+		},
+		{
+			src:   "3.14,15 a1_b",
+			words: []string{"3.14,15", " ", "a1_b"},
+			rules: []Rule{"WB1", "WB12", "WB11", "WB8", "WB12", "WB11", "WB8", "WB999", "WB999", "WB9", "WB13a", "WB13b", "WB2"},
+		},
+		{
+			src:   "a\u0301\u00ADb.",
+			words: []string{"a\u0301\u00ADb", "."},
+			rules: []Rule{"WB1", "WB4", "WB4", "WB5", "WB999", "WB2"},
+		},
+		{
+			src:   "\r\n\u0301a",
+			words: []string{"\r\n", "\u0301", "a"},
+			rules: []Rule{"WB1", "WB3", "WB3a", "WB999", "WB2"},
+		},
+		{
+			src:   "א\"ב'アア",
+			words: []string{"א\"ב'", "アア"},
+			rules: []Rule{"WB1", "WB7b", "WB7c", "WB7a", "WB999", "WB13", "WB2"},
+		},
+		{
+			src:   "\U0001F1EF\U0001F1F5\U0001F1EF\u200D\U0001F466",
+			words: []string{"\U0001F1EF\U0001F1F5", "\U0001F1EF\u200D\U0001F466"},
+			rules: []Rule{"WB1", "WB15", "WB999", "WB4", "WB3c", "WB2"},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			src := []rune(tt.src)
+			var words []string
+			for _, w := range Split(b, src) {
+				words = append(words, string(w))
+			}
+			if fmt.Sprintf("%q", words) != fmt.Sprintf("%q", tt.words) {
+				t.Fatalf("unexpected words: want: %q, got: %q", tt.words, words)
+			}
+			bounds := b.Boundaries(src)
+			if len(bounds) != len(tt.rules) {
+				t.Fatalf("unexpected boundaries: want: %v, got: %v", len(tt.rules), len(bounds))
+			}
+			for i, bound := range bounds {
+				if bound.Rule != tt.rules[i] {
+					t.Fatalf("unexpected rule at %v: want: %v, got: %v", i, tt.rules[i], bound.Rule)
+				}
+			}
+		})
+	}
+}
@@ -5,14 +5,40 @@ import "fmt"
 const UnicodeVersion = "13.0.0"
 
 const (
-	TxtUnicodeData           = "UnicodeData.txt"
-	TxtNameAliases           = "NameAliases.txt"
-	TxtDerivedCoreProperties = "DerivedCoreProperties.txt"
-	TxtPropertyAliases       = "PropertyAliases.txt"
-	TxtPropertyValueAliases  = "PropertyValueAliases.txt"
-	TxtPropList              = "PropList.txt"
+	TxtUnicodeData               = "UnicodeData.txt"
+	TxtNameAliases               = "NameAliases.txt"
+	TxtDerivedCoreProperties     = "DerivedCoreProperties.txt"
+	TxtPropertyAliases           = "PropertyAliases.txt"
+	TxtPropertyValueAliases      = "PropertyValueAliases.txt"
+	TxtPropList                  = "PropList.txt"
+	TxtScripts                   = "Scripts.txt"
+	TxtScriptExtensions          = "ScriptExtensions.txt"
+	TxtBlocks                    = "Blocks.txt"
+	TxtDerivedNormalizationProps = "DerivedNormalizationProps.txt"
+	TxtLineBreak                 = "LineBreak.txt"
+	TxtEastAsianWidth            = "EastAsianWidth.txt"
+	TxtBidiBrackets              = "BidiBrackets.txt"
+	TxtBidiMirroring             = "BidiMirroring.txt"
+	TxtCaseFolding               = "CaseFolding.txt"
+	TxtSpecialCasing             = "SpecialCasing.txt"
+	TxtGraphemeBreakProperty     = "auxiliary/GraphemeBreakProperty.txt"
+	TxtWordBreakProperty         = "auxiliary/WordBreakProperty.txt"
+	TxtSentenceBreakProperty     = "auxiliary/SentenceBreakProperty.txt"
+	TxtEmojiData                 = "emoji/emoji-data.txt"
 )
 
+// The following files are test data for conformance testing.
+const (
+	TxtNormalizationTest = "NormalizationTest.txt"
+	TxtGraphemeBreakTest = "auxiliary/GraphemeBreakTest.txt"
+	TxtWordBreakTest     = "auxiliary/WordBreakTest.txt"
+	TxtSentenceBreakTest = "auxiliary/SentenceBreakTest.txt"
+	TxtLineBreakTest     = "auxiliary/LineBreakTest.txt"
+	TxtBidiTest          = "BidiTest.txt"
+	TxtBidiCharacterTest = "BidiCharacterTest.txt"
+)
+
+// MakeDataFileURL returns a URL of a data file. Data file names may contain a subdirectory such as `auxiliary/`.
 func MakeDataFileURL(dataFileName string) string {
 	return fmt.Sprintf("https://www.unicode.org/Public/%v/ucd/%v", UnicodeVersion, dataFileName)
 }
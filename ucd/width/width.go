@@ -0,0 +1,227 @@
+// Package width calculates the number of columns text occupies in terminals using the East_Asian_Width property.
+//
+// The width of text is the sum of the widths of its extended grapheme clusters. See [UAX11] for more details on the
+// East_Asian_Width property and [UAX29] for the grapheme clusters.
+package width
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nihei9/ucdx/ucd/property"
+	"github.com/nihei9/ucdx/ucd/segment"
+)
+
+// AmbiguousPolicy decides the width of characters whose East_Asian_Width is Ambiguous (A). Such characters are
+// narrow in non-East Asian contexts and wide in East Asian contexts. See section 5 Recommendations in [UAX11].
+type AmbiguousPolicy int
+
+const (
+	AmbiguousNarrow AmbiguousPolicy = iota
+	AmbiguousWide
+)
+
+var ambiguousPolicyNames = map[AmbiguousPolicy]string{
+	AmbiguousNarrow: "narrow",
+	AmbiguousWide:   "wide",
+}
+
+func (p AmbiguousPolicy) String() string {
+	if name, ok := ambiguousPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("AmbiguousPolicy(%d)", int(p))
+}
+
+func (p AmbiguousPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// ParseAmbiguousPolicy returns a policy corresponding to a name such as `narrow`. The name is case-insensitive.
+func ParseAmbiguousPolicy(name string) (AmbiguousPolicy, error) {
+	for p, n := range ambiguousPolicyNames {
+		if strings.EqualFold(name, n) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown ambiguous width policy: %v", name)
+}
+
+const (
+	eawUnlisted = iota
+	eawNarrow
+	eawWide
+	eawAmbiguous
+)
+
+// eawClasses maps the East_Asian_Width values to their widths. Halfwidth (H) and Narrow (Na) characters are
+// narrow, and so are Neutral (N) characters in practice.
+var eawClasses = map[property.PropertyValueSymbol]int{
+	"n":  eawNarrow,
+	"na": eawNarrow,
+	"h":  eawNarrow,
+	"w":  eawWide,
+	"f":  eawWide,
+	"a":  eawAmbiguous,
+}
+
+const (
+	variationSelectorText  = 0xFE0E
+	variationSelectorEmoji = 0xFE0F
+)
+
+// Calculator calculates the width of text.
+type Calculator struct {
+	eaw         rangeTable
+	eawDefaults []*property.DefaultValue
+	zero        rangeTable
+	ri          rangeTable
+	breaker     *segment.GraphemeBreaker
+	policy      AmbiguousPolicy
+}
+
+// NewCalculator builds a calculator from the East_Asian_Width property, the General_Category property, and the
+// data the grapheme cluster boundaries need.
+func NewCalculator(eaw *property.EastAsianWidth, ud *property.UnicodeData, gbp *property.GraphemeBreakProperty, emoji *property.EmojiData, policy AmbiguousPolicy) *Calculator {
+	return &Calculator{
+		eaw:         newRangeTable(eaw.Entries, eawClasses),
+		eawDefaults: eaw.Defaults,
+		// Nonspacing marks, enclosing marks, format characters, and control characters occupy no columns.
+		zero: newRangeTable(ud.GeneralCategory, map[property.PropertyValueSymbol]int{
+			"mn": 1,
+			"me": 1,
+			"cf": 1,
+			"cc": 1,
+		}),
+		ri: newRangeTable(gbp.Entries, map[property.PropertyValueSymbol]int{
+			"regionalindicator": 1,
+		}),
+		breaker: segment.NewGraphemeBreaker(gbp, emoji),
+		policy:  policy,
+	}
+}
+
+// String returns the width of a string.
+func (c *Calculator) String(s string) int {
+	return c.Runes([]rune(s))
+}
+
+// Runes returns the width of a sequence of code points.
+func (c *Calculator) Runes(rs []rune) int {
+	w := 0
+	for _, cluster := range segment.Split(c.breaker, rs) {
+		w += c.Cluster(cluster)
+	}
+	return w
+}
+
+// Clusters splits a sequence of code points into grapheme clusters and returns the width of each cluster.
+func (c *Calculator) Clusters(rs []rune) ([][]rune, []int) {
+	clusters := segment.Split(c.breaker, rs)
+	ws := make([]int, len(clusters))
+	for i, cluster := range clusters {
+		ws[i] = c.Cluster(cluster)
+	}
+	return clusters, ws
+}
+
+// Cluster returns the width of a grapheme cluster. The width is decided by the first code point occupying columns,
+// called the base, as follows:
+//
+//   - A cluster without a base occupies no columns.
+//   - A pair of regional indicators, that is a flag, occupies two columns.
+//   - A variation selector requests the emoji presentation (U+FE0F) or the text presentation (U+FE0E), which
+//     occupies two columns or one column respectively.
+//   - Otherwise, the East_Asian_Width of the base decides the width.
+func (c *Calculator) Cluster(cluster []rune) int {
+	base := -1
+	for i, r := range cluster {
+		if c.zero.lookup(r) == 0 {
+			base = i
+			break
+		}
+	}
+	if base < 0 {
+		return 0
+	}
+
+	if c.ri.lookup(cluster[base]) == 1 {
+		if base+1 < len(cluster) && c.ri.lookup(cluster[base+1]) == 1 {
+			return 2
+		}
+		return 1
+	}
+	for _, r := range cluster[base+1:] {
+		switch r {
+		case variationSelectorEmoji:
+			return 2
+		case variationSelectorText:
+			return 1
+		}
+	}
+
+	switch c.lookupEAW(cluster[base]) {
+	case eawWide:
+		return 2
+	case eawAmbiguous:
+		if c.policy == AmbiguousWide {
+			return 2
+		}
+	}
+	return 1
+}
+
+func (c *Calculator) lookupEAW(r rune) int {
+	if class := c.eaw.lookup(r); class != eawUnlisted {
+		return class
+	}
+	if v, ok := property.LookupDefaultValue(c.eawDefaults, r); ok {
+		return eawClasses[v]
+	}
+	return eawNarrow
+}
+
+type valueRange struct {
+	from  rune
+	to    rune
+	value int
+}
+
+// rangeTable maps code points to values. The table is sorted by code points so that it can be searched in
+// logarithmic time.
+type rangeTable []*valueRange
+
+// newRangeTable makes a table from the data of an enumerated property. Values not included in `values` are
+// ignored, and code points having such values are mapped to 0.
+func newRangeTable(entries map[property.PropertyValueSymbol][]*property.CodePointRange, values map[property.PropertyValueSymbol]int) rangeTable {
+	var t rangeTable
+	for v, cps := range entries {
+		value, ok := values[v]
+		if !ok {
+			continue
+		}
+		for _, cp := range cps {
+			from, to := cp.Range()
+			t = append(t, &valueRange{
+				from:  from,
+				to:    to,
+				value: value,
+			})
+		}
+	}
+	sort.Slice(t, func(i, j int) bool {
+		return t[i].from < t[j].from
+	})
+	return t
+}
+
+func (t rangeTable) lookup(c rune) int {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].to >= c
+	})
+	if i < len(t) && t[i].from <= c {
+		return t[i].value
+	}
+	return 0
+}
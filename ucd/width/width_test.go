@@ -0,0 +1,134 @@
+package width
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nihei9/ucdx/ucd/parser"
+)
+
+const testEastAsianWidth = `
+# @missing: 0000..10FFFF; N
+# @missing: 3400..4DBF; W
+0020..007E;Na    # Zs+   [95] SPACE..TILDE
+00A1;A           # Po         INVERTED EXCLAMATION MARK
+0300..036F;A     # Mn   [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+3042;W           # Lo         HIRAGANA LETTER A
+FF21..FF3A;F     # Lu    [26] FULLWIDTH LATIN CAPITAL LETTER A..FULLWIDTH LATIN CAPITAL LETTER Z
+FF76;H           # Lo         HALFWIDTH KATAKANA LETTER KA
+1F1E6..1F1FF;N   # So    [26] REGIONAL INDICATOR SYMBOL LETTER A..REGIONAL INDICATOR SYMBOL LETTER Z
+1F466..1F469;W   # So     [4] BOY..WOMAN
+`
+
+const testUnicodeData = `
+0007;<control>;Cc;0;BN;;;;;N;BELL;;;;
+0061;LATIN SMALL LETTER A;Ll;0;L;;;;;N;;;0041;;0041
+0301;COMBINING ACUTE ACCENT;Mn;230;NSM;0301;;;;N;NON-SPACING ACUTE;;;;
+200D;ZERO WIDTH JOINER;Cf;0;BN;;;;;N;;;;;
+2764;HEAVY BLACK HEART;So;0;ON;;;;;N;;;;;
+FE0F;VARIATION SELECTOR-16;Mn;0;NSM;;;;;N;;;;;
+`
+
+const testGraphemeBreakProperty = `
+0000..0009    ; Control # Cc  [10] <control-0000>..<control-0009>
+0300..036F    ; Extend # Mn [112] COMBINING GRAVE ACCENT..COMBINING LATIN SMALL LETTER X
+FE0F          ; Extend # Mn       VARIATION SELECTOR-16
+200D          ; ZWJ # Cf       ZERO WIDTH JOINER
+1F1E6..1F1FF  ; Regional_Indicator # So  [26] REGIONAL INDICATOR SYMBOL LETTER A..REGIONAL INDICATOR SYMBOL LETTER Z
+`
+
+const testEmojiData = `
+2764          ; Extended_Pictographic# E0.6   [1] (❤️)       red heart
+1F466..1F469  ; Extended_Pictographic# E0.6   [4] (👦..👩)    boy..woman
+`
+
+func TestCalculator(t *testing.T) {
+	eaw, err := parser.ParseEastAsianWidth(strings.NewReader(testEastAsianWidth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ud, err := parser.ParseUnicodeData(strings.NewReader(testUnicodeData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gbp, err := parser.ParseGraphemeBreakProperty(strings.NewReader(testGraphemeBreakProperty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emoji, err := parser.ParseEmojiData(strings.NewReader(testEmojiData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		src    string
+		policy AmbiguousPolicy
+		widths []int
+	}{
+		{
+			src:    "a\u0301b",
+			widths: []int{1, 1},
+		},
+		{
+			src:    "\u3042\uFF21\uFF76",
+			widths: []int{2, 2, 1},
+		},
+		{
+			src:    "\u00A1\u0301",
+			widths: []int{1},
+		},
+		{
+			src:    "\u00A1\u0301",
+			policy: AmbiguousWide,
+			widths: []int{2},
+		},
+		{
+			src:    "\u3400\U00020000",
+			widths: []int{2, 1},
+		},
+		{
+			src:    "\U0001F468\u200D\U0001F469\u200D\U0001F466\u2764\u2764\uFE0F",
+			widths: []int{2, 1, 2},
+		},
+		{
+			src:    "\U0001F1EF\U0001F1F5\U0001F1EF",
+			widths: []int{2, 1},
+		},
+		{
+			src:    "\u0007\u0301",
+			widths: []int{0, 0},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%v", i), func(t *testing.T) {
+			c := NewCalculator(eaw, ud, gbp, emoji, tt.policy)
+			_, ws := c.Clusters([]rune(tt.src))
+			if fmt.Sprint(ws) != fmt.Sprint(tt.widths) {
+				t.Fatalf("unexpected widths: want: %v, got: %v", tt.widths, ws)
+			}
+			total := 0
+			for _, w := range tt.widths {
+				total += w
+			}
+			if w := c.String(tt.src); w != total {
+				t.Fatalf("unexpected width: want: %v, got: %v", total, w)
+			}
+		})
+	}
+}
+
+func TestParseAmbiguousPolicy(t *testing.T) {
+	for _, name := range []string{"narrow", "Wide"} {
+		p, err := ParseAmbiguousPolicy(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(p.String(), name) {
+			t.Fatalf("unexpected policy: want: %v, got: %v", name, p)
+		}
+	}
+	if _, err := ParseAmbiguousPolicy("half"); err == nil {
+		t.Fatal("an error is expected")
+	}
+}